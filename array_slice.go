@@ -0,0 +1,685 @@
+package gods
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ArraySlice is a Slice backed by a Go slice of interface{}. Methods
+// documented as in place mutate the ArraySlice and return it, while the
+// others return a new ArraySlice and leave it unchanged.
+type ArraySlice struct {
+	raw []interface{}
+}
+
+var _ Slice = (*ArraySlice)(nil)
+
+// NewArraySlice creates an ArraySlice holding a copy of values.
+func NewArraySlice(values ...interface{}) *ArraySlice {
+	return &ArraySlice{raw: append([]interface{}(nil), values...)}
+}
+
+// Empty indicates if the ArraySlice is empty.
+func (s *ArraySlice) Empty() bool {
+	return len(s.raw) == 0
+}
+
+// Size retrieves ArraySlice size.
+func (s *ArraySlice) Size() int {
+	return len(s.raw)
+}
+
+// Clear resets ArraySlice, it will be empty with size 0.
+func (s *ArraySlice) Clear() {
+	s.raw = nil
+}
+
+// RangeWithIndex iterates ArraySlice elements in order.
+// Stop iterating if the IndexRangerFunc returns false.
+func (s *ArraySlice) RangeWithIndex(fn IndexRangerFunc) {
+	for i, v := range s.raw {
+		if !fn(i, v) {
+			return
+		}
+	}
+}
+
+// Raw returns the backing slice of ArraySlice, not a copy.
+func (s *ArraySlice) Raw() []interface{} {
+	return s.raw
+}
+
+// Pop removes the last element from ArraySlice and returns it.
+// Returns (nil, false) if there is no more element.
+func (s *ArraySlice) Pop() (interface{}, bool) {
+	if len(s.raw) == 0 {
+		return nil, false
+	}
+	last := len(s.raw) - 1
+	v := s.raw[last]
+	s.raw[last] = nil
+	s.raw = s.raw[:last]
+	return v, true
+}
+
+// PopFront removes the first element from ArraySlice and returns it.
+// Returns (nil, false) if there is no more element.
+func (s *ArraySlice) PopFront() (interface{}, bool) {
+	if len(s.raw) == 0 {
+		return nil, false
+	}
+	v := s.raw[0]
+	s.raw[0] = nil
+	s.raw = s.raw[1:]
+	return v, true
+}
+
+// Append appends new elements to the end of ArraySlice, in place.
+func (s *ArraySlice) Append(values ...interface{}) Slice {
+	s.raw = append(s.raw, values...)
+	return s
+}
+
+// Prepend inserts new elements at the start of ArraySlice, in place.
+func (s *ArraySlice) Prepend(values ...interface{}) Slice {
+	s.raw = append(append(make([]interface{}, 0, len(values)+len(s.raw)), values...), s.raw...)
+	return s
+}
+
+// Concat returns a new ArraySlice with the elements of ArraySlice followed
+// by those of slice.
+func (s *ArraySlice) Concat(slice Slice) Slice {
+	other := slice.Raw()
+	raw := make([]interface{}, 0, len(s.raw)+len(other))
+	return &ArraySlice{raw: append(append(raw, s.raw...), other...)}
+}
+
+// Intersperse returns a new ArraySlice with sep inserted between each pair
+// of adjacent elements.
+func (s *ArraySlice) Intersperse(sep interface{}) Slice {
+	result := &ArraySlice{}
+	for i, v := range s.raw {
+		if i > 0 {
+			result.raw = append(result.raw, sep)
+		}
+		result.raw = append(result.raw, v)
+	}
+	return result
+}
+
+// Join converts all the elements of ArraySlice into strings with format
+// and concatenates them, separated by sep.
+func (s *ArraySlice) Join(sep string, format func(interface{}) string) string {
+	var b strings.Builder
+	for i, v := range s.raw {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(format(v))
+	}
+	return b.String()
+}
+
+// Union returns a new ArraySlice with the distinct elements of ArraySlice
+// then of other, in order of first occurrence. Panics if an element is not
+// comparable, see MustBeComparable.
+func (s *ArraySlice) Union(other Slice) Slice {
+	result := &ArraySlice{}
+	seen := make(map[interface{}]struct{})
+	for _, raw := range [][]interface{}{s.raw, other.Raw()} {
+		for _, v := range raw {
+			MustBeComparable(v)
+			if _, ok := seen[v]; !ok {
+				seen[v] = struct{}{}
+				result.raw = append(result.raw, v)
+			}
+		}
+	}
+	return result
+}
+
+// Intersect returns a new ArraySlice with the distinct elements of
+// ArraySlice which are also in other, in order of first occurrence. Panics
+// if an element is not comparable, see MustBeComparable.
+func (s *ArraySlice) Intersect(other Slice) Slice {
+	in := rawSet(other.Raw())
+	result := &ArraySlice{}
+	for _, v := range s.raw {
+		MustBeComparable(v)
+		if _, ok := in[v]; ok {
+			delete(in, v)
+			result.raw = append(result.raw, v)
+		}
+	}
+	return result
+}
+
+// SubtractSlice returns a new ArraySlice with the elements of ArraySlice
+// which are not in other, keeping their duplicates and order. Panics if an
+// element is not comparable, see MustBeComparable.
+func (s *ArraySlice) SubtractSlice(other Slice) Slice {
+	in := rawSet(other.Raw())
+	result := &ArraySlice{}
+	for _, v := range s.raw {
+		MustBeComparable(v)
+		if _, ok := in[v]; !ok {
+			result.raw = append(result.raw, v)
+		}
+	}
+	return result
+}
+
+// rawSet returns the set of the elements of raw. Panics if an element is
+// not comparable, see MustBeComparable.
+func rawSet(raw []interface{}) map[interface{}]struct{} {
+	set := make(map[interface{}]struct{}, len(raw))
+	for _, v := range raw {
+		MustBeComparable(v)
+		set[v] = struct{}{}
+	}
+	return set
+}
+
+// Reverse reverses the elements of ArraySlice in place.
+func (s *ArraySlice) Reverse() Slice {
+	reverseRaw(s.raw)
+	return s
+}
+
+// Reversed returns a new ArraySlice with the elements in reverse order,
+// leaving ArraySlice unchanged.
+func (s *ArraySlice) Reversed() Slice {
+	raw := make([]interface{}, len(s.raw))
+	for i, v := range s.raw {
+		raw[len(raw)-1-i] = v
+	}
+	return &ArraySlice{raw: raw}
+}
+
+// ReverseRange reverses the elements in the [i, j) range of ArraySlice in
+// place. Panics unless 0 <= i <= j <= Size.
+func (s *ArraySlice) ReverseRange(i, j int) Slice {
+	if i < 0 || i > j || j > len(s.raw) {
+		panic(fmt.Sprintf("gods: range [%d, %d) out of bounds of a Slice of size %d", i, j, len(s.raw)))
+	}
+	reverseRaw(s.raw[i:j])
+	return s
+}
+
+func reverseRaw(raw []interface{}) {
+	for i, j := 0, len(raw)-1; i < j; i, j = i+1, j-1 {
+		raw[i], raw[j] = raw[j], raw[i]
+	}
+}
+
+// Rotate cyclically shifts the elements of ArraySlice in place by k
+// positions to the right, or to the left if k is negative, in O(n) with
+// three reversals.
+func (s *ArraySlice) Rotate(k int) Slice {
+	n := len(s.raw)
+	if n == 0 {
+		return s
+	}
+	if k %= n; k < 0 {
+		k += n
+	}
+	reverseRaw(s.raw)
+	reverseRaw(s.raw[:k])
+	reverseRaw(s.raw[k:])
+	return s
+}
+
+// relativeIndex resolves index against size: a negative index counts back
+// from the end, and the result is clamped to [0, size].
+func relativeIndex(index, size int) int {
+	if index < 0 {
+		index += size
+		if index < 0 {
+			return 0
+		}
+	} else if index > size {
+		return size
+	}
+	return index
+}
+
+// CopyWithin shallow copies the elements in the [start, end) range of
+// ArraySlice to the position starting at target, in place, without
+// changing its size. Negative indexes count back from the end, and out of
+// range indexes are clamped to the bounds of ArraySlice.
+func (s *ArraySlice) CopyWithin(target, start, end int) Slice {
+	n := len(s.raw)
+	target, start, end = relativeIndex(target, n), relativeIndex(start, n), relativeIndex(end, n)
+	if start < end {
+		// copy handles overlapping ranges.
+		copy(s.raw[target:], s.raw[start:end])
+	}
+	return s
+}
+
+// Fill sets the elements in the [start, end) range of ArraySlice to value,
+// in place. Negative indexes count back from the end, and out of range
+// indexes are clamped to the bounds of ArraySlice.
+func (s *ArraySlice) Fill(value interface{}, start, end int) Slice {
+	n := len(s.raw)
+	for i := relativeIndex(start, n); i < relativeIndex(end, n); i++ {
+		s.raw[i] = value
+	}
+	return s
+}
+
+// Sort sorts ArraySlice in place. compare reports whether raw[i] must sort
+// before raw[j].
+func (s *ArraySlice) Sort(compare func(raw []interface{}, i, j int) bool) Slice {
+	sort.Slice(s.raw, func(i, j int) bool { return compare(s.raw, i, j) })
+	return s
+}
+
+// InsertSorted inserts value in place into ArraySlice sorted in ascending
+// order by cmp, after the elements comparing equal to it. The position is
+// binary searched, so it takes O(log n) comparisons.
+func (s *ArraySlice) InsertSorted(value interface{}, cmp func(a, b interface{}) int) Slice {
+	i := sort.Search(len(s.raw), func(i int) bool { return cmp(s.raw[i], value) > 0 })
+	s.raw = append(s.raw, nil)
+	copy(s.raw[i+1:], s.raw[i:])
+	s.raw[i] = value
+	return s
+}
+
+// SearchSorted binary searches value in ArraySlice sorted in ascending order
+// by cmp. It returns the index of the first element comparing equal to
+// value and true, or the index where value would be inserted and false.
+func (s *ArraySlice) SearchSorted(value interface{}, cmp func(a, b interface{}) int) (index int, found bool) {
+	i := sort.Search(len(s.raw), func(i int) bool { return cmp(s.raw[i], value) >= 0 })
+	return i, i < len(s.raw) && cmp(s.raw[i], value) == 0
+}
+
+// Slice returns a new ArraySlice with a copy of the elements in the
+// [start, end) range given as arguments, which default to 0 and Size.
+// Negative indexes count back from the end, and out of range indexes are
+// clamped to the bounds of ArraySlice.
+func (s *ArraySlice) Slice(args ...int) Slice {
+	start, end := 0, len(s.raw)
+	if len(args) > 0 {
+		start = relativeIndex(args[0], len(s.raw))
+	}
+	if len(args) > 1 {
+		end = relativeIndex(args[1], len(s.raw))
+	}
+	if end < start {
+		end = start
+	}
+	return NewArraySlice(s.raw[start:end]...)
+}
+
+// Window returns an ArraySlice of overlapping ArraySlices of the given
+// size, sliding by one element. Returns an empty ArraySlice if size is
+// larger than ArraySlice, and panics if size is not positive.
+func (s *ArraySlice) Window(size int) Slice {
+	if size <= 0 {
+		panic("gods: window size must be positive")
+	}
+	result := &ArraySlice{}
+	for i := 0; i+size <= len(s.raw); i++ {
+		result.raw = append(result.raw, NewArraySlice(s.raw[i:i+size]...))
+	}
+	return result
+}
+
+// Cycle returns an Iterator yielding the elements of ArraySlice in order
+// forever, see NewCycleIterator.
+func (s *ArraySlice) Cycle() Iterator {
+	return NewCycleIterator(s)
+}
+
+// SplitAt returns two new ArraySlices holding the elements before index and
+// the ones from index on. index is clamped to the [0, Size] range.
+func (s *ArraySlice) SplitAt(index int) (left Slice, right Slice) {
+	if index < 0 {
+		index = 0
+	} else if index > len(s.raw) {
+		index = len(s.raw)
+	}
+	return NewArraySlice(s.raw[:index]...), NewArraySlice(s.raw[index:]...)
+}
+
+// SplitBy returns an ArraySlice of ArraySlices holding the runs of elements
+// between the elements satisfying isDelimiter, which are dropped.
+func (s *ArraySlice) SplitBy(isDelimiter func(interface{}) bool) Slice {
+	result := &ArraySlice{}
+	run := &ArraySlice{}
+	for _, v := range s.raw {
+		if isDelimiter(v) {
+			result.raw = append(result.raw, run)
+			run = &ArraySlice{}
+		} else {
+			run.raw = append(run.raw, v)
+		}
+	}
+	result.raw = append(result.raw, run)
+	return result
+}
+
+// Splice removes deleteCount elements of ArraySlice from start, in place,
+// inserts elements in their place, and returns a new ArraySlice with the
+// deleted elements. A negative deleteCount deletes all the elements from
+// start. A negative start is an offset from the end, and start is clamped
+// to the bounds of ArraySlice.
+func (s *ArraySlice) Splice(start int, deleteCount int, elements ...interface{}) Slice {
+	start = relativeIndex(start, len(s.raw))
+	if deleteCount < 0 || deleteCount > len(s.raw)-start {
+		deleteCount = len(s.raw) - start
+	}
+	deleted := NewArraySlice(s.raw[start : start+deleteCount]...)
+	tail := append(append([]interface{}(nil), elements...), s.raw[start+deleteCount:]...)
+	s.raw = append(s.raw[:start], tail...)
+	return deleted
+}
+
+// Map returns a new ArraySlice with every element projected by project.
+func (s *ArraySlice) Map(project func(interface{}) interface{}) Slice {
+	return s.MapIndexed(func(_ int, v interface{}) interface{} { return project(v) })
+}
+
+// MapIndexed returns a new ArraySlice with every element projected by
+// project, which is also passed its index.
+func (s *ArraySlice) MapIndexed(project func(index int, value interface{}) interface{}) Slice {
+	raw := make([]interface{}, len(s.raw))
+	for i, v := range s.raw {
+		raw[i] = project(i, v)
+	}
+	return &ArraySlice{raw: raw}
+}
+
+// Tap calls fn with ArraySlice itself and returns it unchanged.
+func (s *ArraySlice) Tap(fn func(Slice)) Slice {
+	fn(s)
+	return s
+}
+
+// TapEach calls fn with every element of ArraySlice and returns it
+// unchanged.
+func (s *ArraySlice) TapEach(fn func(interface{})) Slice {
+	for _, v := range s.raw {
+		fn(v)
+	}
+	return s
+}
+
+// ToMap returns a ShardedMap binding the key derived from each element by
+// keyFn to the element. If several elements derive the same key, the last
+// one wins.
+func (s *ArraySlice) ToMap(keyFn func(interface{}) interface{}) Map {
+	return s.ToMapBy(keyFn, func(v interface{}) interface{} { return v })
+}
+
+// ToMapBy is like ToMap, but binds each key to the value projected from the
+// element by valueFn.
+func (s *ArraySlice) ToMapBy(keyFn, valueFn func(interface{}) interface{}) Map {
+	m := NewShardedMap()
+	for _, v := range s.raw {
+		m.Add(keyFn(v), valueFn(v))
+	}
+	return m
+}
+
+// Filter returns a new ArraySlice with the elements satisfying predicate.
+func (s *ArraySlice) Filter(predicate func(interface{}) bool) Slice {
+	return s.FilterIndexed(func(_ int, v interface{}) bool { return predicate(v) })
+}
+
+// FilterIndexed returns a new ArraySlice with the elements satisfying
+// predicate, which is also passed their index.
+func (s *ArraySlice) FilterIndexed(predicate func(index int, value interface{}) bool) Slice {
+	result := &ArraySlice{}
+	for i, v := range s.raw {
+		if predicate(i, v) {
+			result.raw = append(result.raw, v)
+		}
+	}
+	return result
+}
+
+// Reject returns a new ArraySlice with the elements not satisfying
+// predicate.
+func (s *ArraySlice) Reject(predicate func(interface{}) bool) Slice {
+	return s.Filter(func(v interface{}) bool { return !predicate(v) })
+}
+
+// FilterMap returns a new ArraySlice with the values projected by fn for
+// which it returns true, in a single pass.
+func (s *ArraySlice) FilterMap(fn func(interface{}) (interface{}, bool)) Slice {
+	result := &ArraySlice{}
+	for _, v := range s.raw {
+		if projected, ok := fn(v); ok {
+			result.raw = append(result.raw, projected)
+		}
+	}
+	return result
+}
+
+// Compact returns a new ArraySlice without the nil elements.
+func (s *ArraySlice) Compact() Slice {
+	return s.CompactBy(func(v interface{}) bool { return v == nil })
+}
+
+// CompactBy returns a new ArraySlice without the elements for which
+// isEmpty returns true.
+func (s *ArraySlice) CompactBy(isEmpty func(interface{}) bool) Slice {
+	return s.Reject(isEmpty)
+}
+
+// Dedup returns a new ArraySlice where each run of consecutive elements
+// equal with == is collapsed into its first element.
+func (s *ArraySlice) Dedup() Slice {
+	return s.DedupBy(func(a, b interface{}) bool { return a == b })
+}
+
+// DedupBy is like Dedup, but compares consecutive elements with eq.
+func (s *ArraySlice) DedupBy(eq func(a, b interface{}) bool) Slice {
+	result := &ArraySlice{}
+	for i, v := range s.raw {
+		if i == 0 || !eq(s.raw[i-1], v) {
+			result.raw = append(result.raw, v)
+		}
+	}
+	return result
+}
+
+// OfType returns a new ArraySlice with the elements whose dynamic type is
+// the same as the one of example.
+func (s *ArraySlice) OfType(example interface{}) Slice {
+	t := reflect.TypeOf(example)
+	return s.Filter(func(v interface{}) bool { return reflect.TypeOf(v) == t })
+}
+
+// OfTypeName returns a new ArraySlice with the elements whose dynamic type
+// is named name, as formatted by the %T verb.
+func (s *ArraySlice) OfTypeName(name string) Slice {
+	return s.Filter(func(v interface{}) bool { return fmt.Sprintf("%T", v) == name })
+}
+
+// Every determines whether all the elements of ArraySlice satisfy
+// predicate. It is true for an empty ArraySlice.
+func (s *ArraySlice) Every(predicate func(interface{}) bool) bool {
+	for _, v := range s.raw {
+		if !predicate(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Some determines whether predicate returns true for any element of
+// ArraySlice.
+func (s *ArraySlice) Some(predicate func(interface{}) bool) bool {
+	for _, v := range s.raw {
+		if predicate(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// EqualSlice determines whether ArraySlice and other have the same size and
+// all their corresponding elements are equal according to eq.
+func (s *ArraySlice) EqualSlice(other Slice, eq func(a, b interface{}) bool) bool {
+	raw := other.Raw()
+	if len(raw) != len(s.raw) {
+		return false
+	}
+	for i, v := range s.raw {
+		if !eq(v, raw[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// DeepEqualSlice is like EqualSlice, comparing elements with
+// reflect.DeepEqual.
+func (s *ArraySlice) DeepEqualSlice(other Slice) bool {
+	return s.EqualSlice(other, reflect.DeepEqual)
+}
+
+// IndexOfBy returns the index of the first element of ArraySlice equal to
+// value according to eq, or -1 if there is none.
+func (s *ArraySlice) IndexOfBy(value interface{}, eq func(a, b interface{}) bool) int {
+	for i, v := range s.raw {
+		if eq(v, value) {
+			return i
+		}
+	}
+	return -1
+}
+
+// IncludesBy determines whether ArraySlice has an element equal to value
+// according to eq.
+func (s *ArraySlice) IncludesBy(value interface{}, eq func(a, b interface{}) bool) bool {
+	return s.IndexOfBy(value, eq) >= 0
+}
+
+// MinBy returns the element of ArraySlice with the lowest key, the first
+// one in case of ties. Returns (nil, false) if ArraySlice is empty.
+func (s *ArraySlice) MinBy(key func(interface{}) float64) (interface{}, bool) {
+	return s.extremumBy(key, func(k, best float64) bool { return k < best })
+}
+
+// MaxBy returns the element of ArraySlice with the highest key, the first
+// one in case of ties. Returns (nil, false) if ArraySlice is empty.
+func (s *ArraySlice) MaxBy(key func(interface{}) float64) (interface{}, bool) {
+	return s.extremumBy(key, func(k, best float64) bool { return k > best })
+}
+
+// extremumBy returns the first element whose key is better than the keys of
+// all the elements before it.
+func (s *ArraySlice) extremumBy(key func(interface{}) float64, better func(k, best float64) bool) (interface{}, bool) {
+	if len(s.raw) == 0 {
+		return nil, false
+	}
+	extremum, best := s.raw[0], key(s.raw[0])
+	for _, v := range s.raw[1:] {
+		if k := key(v); better(k, best) {
+			extremum, best = v, k
+		}
+	}
+	return extremum, true
+}
+
+// SumBy returns the sum of the keys of all the elements of ArraySlice.
+func (s *ArraySlice) SumBy(key func(interface{}) float64) float64 {
+	sum := 0.0
+	for _, v := range s.raw {
+		sum += key(v)
+	}
+	return sum
+}
+
+// AverageBy returns the average of the keys of all the elements of
+// ArraySlice. Returns (0, false) if ArraySlice is empty.
+func (s *ArraySlice) AverageBy(key func(interface{}) float64) (float64, bool) {
+	if len(s.raw) == 0 {
+		return 0, false
+	}
+	return s.SumBy(key) / float64(len(s.raw)), true
+}
+
+// Reduce calls fn for all the elements of ArraySlice in order, with the
+// result of the previous call, initialValue for the first one, and returns
+// the last result.
+func (s *ArraySlice) Reduce(fn func(previousValue, currentValue interface{}, currentIndex int) interface{}, initialValue interface{}) interface{} {
+	acc := initialValue
+	for i, v := range s.raw {
+		acc = fn(acc, v, i)
+	}
+	return acc
+}
+
+// ReduceRight is like Reduce, but calls fn for the elements in descending
+// order.
+func (s *ArraySlice) ReduceRight(fn func(previousValue, currentValue interface{}, currentIndex int) interface{}, initialValue interface{}) interface{} {
+	acc := initialValue
+	for i := len(s.raw) - 1; i >= 0; i-- {
+		acc = fn(acc, s.raw[i], i)
+	}
+	return acc
+}
+
+// ReduceWhile is like Reduce, but stops as soon as fn returns false,
+// returning the result of that last call.
+func (s *ArraySlice) ReduceWhile(fn func(acc, cur interface{}, index int) (interface{}, bool), initial interface{}) interface{} {
+	acc := initial
+	for i, v := range s.raw {
+		var ok bool
+		if acc, ok = fn(acc, v, i); !ok {
+			break
+		}
+	}
+	return acc
+}
+
+// ReduceLeaves is like Reduce, but descends recursively into the elements
+// which are Slices or []interface{}, folding only the other elements.
+func (s *ArraySlice) ReduceLeaves(fn func(acc, leaf interface{}) interface{}, initial interface{}) interface{} {
+	return reduceLeaves(s.raw, fn, initial)
+}
+
+func reduceLeaves(raw []interface{}, fn func(acc, leaf interface{}) interface{}, acc interface{}) interface{} {
+	for _, v := range raw {
+		switch nested := v.(type) {
+		case Slice:
+			acc = reduceLeaves(nested.Raw(), fn, acc)
+		case []interface{}:
+			acc = reduceLeaves(nested, fn, acc)
+		default:
+			acc = fn(acc, v)
+		}
+	}
+	return acc
+}
+
+// Scan is like Reduce, but returns a new ArraySlice of all the intermediate
+// results.
+func (s *ArraySlice) Scan(fn func(acc, cur interface{}, index int) interface{}, initial interface{}) Slice {
+	raw := make([]interface{}, len(s.raw))
+	acc := initial
+	for i, v := range s.raw {
+		acc = fn(acc, v, i)
+		raw[i] = acc
+	}
+	return &ArraySlice{raw: raw}
+}
+
+// ScanRight is like ReduceRight, but returns a new ArraySlice of all the
+// intermediate results, in the order they are computed.
+func (s *ArraySlice) ScanRight(fn func(acc, cur interface{}, index int) interface{}, initial interface{}) Slice {
+	raw := make([]interface{}, 0, len(s.raw))
+	acc := initial
+	for i := len(s.raw) - 1; i >= 0; i-- {
+		acc = fn(acc, s.raw[i], i)
+		raw = append(raw, acc)
+	}
+	return &ArraySlice{raw: raw}
+}
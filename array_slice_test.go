@@ -0,0 +1,689 @@
+package gods
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func add(acc, cur interface{}, _ int) interface{} {
+	return acc.(int) + cur.(int)
+}
+
+func expectRaw(t *testing.T, name string, s Slice, want ...interface{}) {
+	t.Helper()
+	if got := s.Raw(); !equalRaw(got, want) {
+		t.Errorf("%s: expected %v, got %v", name, want, got)
+	}
+}
+
+func TestArraySlice(t *testing.T) {
+	values := []interface{}{2, 3}
+	s := NewArraySlice(values...)
+	values[0] = 0
+	expectRaw(t, "NewArraySlice copies values", s, 2, 3)
+
+	s.Append(4, 5).Prepend(0, 1)
+	expectRaw(t, "Append and Prepend", s, 0, 1, 2, 3, 4, 5)
+	if v, ok := s.Pop(); !ok || v != 5 {
+		t.Errorf("expected to pop 5, got (%v, %v)", v, ok)
+	}
+	if v, ok := s.PopFront(); !ok || v != 0 {
+		t.Errorf("expected to pop 0 from the front, got (%v, %v)", v, ok)
+	}
+	expectRaw(t, "Pop and PopFront", s, 1, 2, 3, 4)
+
+	var visited []interface{}
+	s.RangeWithIndex(func(i int, v interface{}) bool {
+		visited = append(visited, v)
+		return i < 1
+	})
+	if !equalRaw(visited, []interface{}{1, 2}) {
+		t.Errorf("expected RangeWithIndex to stop after 2 elements, got %v", visited)
+	}
+
+	even := func(v interface{}) bool { return v.(int)%2 == 0 }
+	expectRaw(t, "Filter", s.Filter(even), 2, 4)
+	expectRaw(t, "Reject", s.Reject(even), 1, 3)
+	expectRaw(t, "Map", s.Map(func(v interface{}) interface{} { return v.(int) * 10 }), 10, 20, 30, 40)
+	expectRaw(t, "Concat", s.Concat(NewArraySlice(5)), 1, 2, 3, 4, 5)
+	expectRaw(t, "Slice", s.Slice(1, 3), 2, 3)
+	expectRaw(t, "Slice from the end", s.Slice(-2), 3, 4)
+	expectRaw(t, "Slice clamped", s.Slice(2, 10), 3, 4)
+	expectRaw(t, "Slice crossed", s.Slice(3, 1))
+	if s.Every(even) || !s.Some(even) || NewArraySlice().Some(even) || !NewArraySlice().Every(even) {
+		t.Error("unexpected Every or Some result")
+	}
+	if got := s.Reduce(add, 0); got != 10 {
+		t.Errorf("expected Reduce to sum to 10, got %v", got)
+	}
+	order := s.ReduceRight(func(acc, cur interface{}, _ int) interface{} {
+		return append(acc.([]interface{}), cur)
+	}, []interface{}(nil))
+	if !equalRaw(order.([]interface{}), []interface{}{4, 3, 2, 1}) {
+		t.Errorf("expected ReduceRight in descending order, got %v", order)
+	}
+	expectRaw(t, "non mutating methods", s, 1, 2, 3, 4)
+
+	expectRaw(t, "Reverse", s.Reverse(), 4, 3, 2, 1)
+	expectRaw(t, "Sort", s.Sort(func(raw []interface{}, i, j int) bool {
+		return raw[i].(int) < raw[j].(int)
+	}), 1, 2, 3, 4)
+
+	s.Clear()
+	if !s.Empty() || s.Size() != 0 {
+		t.Error("expected Clear to empty the slice")
+	}
+	if _, ok := s.Pop(); ok {
+		t.Error("expected popping an empty slice to fail")
+	}
+	if _, ok := s.PopFront(); ok {
+		t.Error("expected popping the front of an empty slice to fail")
+	}
+}
+
+func TestArraySliceSplice(t *testing.T) {
+	tests := []struct {
+		start, deleteCount int
+		elements           []interface{}
+		deleted, left      []interface{}
+	}{
+		{1, 2, nil, []interface{}{1, 2}, []interface{}{0, 3, 4}},
+		{1, 0, []interface{}{"a"}, nil, []interface{}{0, "a", 1, 2, 3, 4}},
+		{2, -1, nil, []interface{}{2, 3, 4}, []interface{}{0, 1}},
+		{3, 10, []interface{}{"a"}, []interface{}{3, 4}, []interface{}{0, 1, 2, "a"}},
+		{-2, 1, nil, []interface{}{3}, []interface{}{0, 1, 2, 4}},
+		{-10, 1, nil, []interface{}{0}, []interface{}{1, 2, 3, 4}},
+		{10, 1, []interface{}{"a"}, nil, []interface{}{0, 1, 2, 3, 4, "a"}},
+	}
+	for _, tt := range tests {
+		s := NewArraySlice(0, 1, 2, 3, 4)
+		deleted := s.Splice(tt.start, tt.deleteCount, tt.elements...)
+		if !equalRaw(deleted.Raw(), tt.deleted) || !equalRaw(s.Raw(), tt.left) {
+			t.Errorf("Splice(%d, %d, %v) deleted %v leaving %v, expected %v leaving %v",
+				tt.start, tt.deleteCount, tt.elements, deleted.Raw(), s.Raw(), tt.deleted, tt.left)
+		}
+	}
+}
+
+func TestArraySliceScan(t *testing.T) {
+	s := NewArraySlice(1, 2, 3)
+	expectRaw(t, "Scan", s.Scan(add, 0), 1, 3, 6)
+	expectRaw(t, "ScanRight", s.ScanRight(add, 0), 3, 5, 6)
+
+	// The accumulator, element and index are threaded through each call.
+	trace := func(acc, cur interface{}, index int) interface{} {
+		return acc.(string) + string(rune('0'+cur.(int))) + string(rune('a'+index))
+	}
+	expectRaw(t, "Scan trace", s.Scan(trace, ">"), ">1a", ">1a2b", ">1a2b3c")
+	expectRaw(t, "ScanRight trace", s.ScanRight(trace, "<"), "<3c", "<3c2b", "<3c2b1a")
+
+	if !NewArraySlice().Scan(add, 0).Empty() || !NewArraySlice().ScanRight(add, 0).Empty() {
+		t.Error("expected scanning an empty slice to return an empty slice")
+	}
+	expectRaw(t, "unchanged", s, 1, 2, 3)
+}
+
+func TestArraySliceCompact(t *testing.T) {
+	var nilPointer *int
+	s := NewArraySlice(nil, 1, "", nil, "a", 0, nilPointer, nil)
+	// A typed nil pointer is not a nil interface.
+	expectRaw(t, "Compact", s.Compact(), 1, "", "a", 0, nilPointer)
+	emptyString := func(v interface{}) bool { return v == "" }
+	expectRaw(t, "CompactBy", NewArraySlice("a", "", "b", "", "").CompactBy(emptyString), "a", "b")
+	expectRaw(t, "Compact of nils", NewArraySlice(nil, nil).Compact())
+	if s.Size() != 8 {
+		t.Error("expected Compact to leave the slice unchanged")
+	}
+}
+
+// nestedRaw returns the raw elements of the Slices held by s.
+func nestedRaw(s Slice) [][]interface{} {
+	var raws [][]interface{}
+	for _, v := range s.Raw() {
+		raws = append(raws, v.(Slice).Raw())
+	}
+	return raws
+}
+
+// panics reports whether fn panics.
+func panics(fn func()) (panicked bool) {
+	defer func() { panicked = recover() != nil }()
+	fn()
+	return false
+}
+
+func TestArraySliceWindow(t *testing.T) {
+	s := NewArraySlice(1, 2, 3, 4)
+	tests := []struct {
+		size int
+		want string
+	}{
+		{1, "[[1] [2] [3] [4]]"},
+		{2, "[[1 2] [2 3] [3 4]]"},
+		{4, "[[1 2 3 4]]"},
+		{5, "[]"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprint(nestedRaw(s.Window(tt.size))); got != tt.want {
+			t.Errorf("Window(%d) = %s, expected %s", tt.size, got, tt.want)
+		}
+	}
+
+	// Windows are copies.
+	s.Window(2).Raw()[0].(Slice).Raw()[0] = 0
+	expectRaw(t, "unchanged", s, 1, 2, 3, 4)
+
+	if !panics(func() { s.Window(0) }) {
+		t.Error("expected a window size of 0 to panic")
+	}
+}
+
+func TestArraySliceRotate(t *testing.T) {
+	tests := []struct {
+		k    int
+		want []interface{}
+	}{
+		{0, []interface{}{1, 2, 3, 4, 5}},
+		{2, []interface{}{4, 5, 1, 2, 3}},
+		{5, []interface{}{1, 2, 3, 4, 5}},
+		{7, []interface{}{4, 5, 1, 2, 3}},
+		{-1, []interface{}{2, 3, 4, 5, 1}},
+		{-12, []interface{}{3, 4, 5, 1, 2}},
+	}
+	for _, tt := range tests {
+		s := NewArraySlice(1, 2, 3, 4, 5)
+		if got := s.Rotate(tt.k); got != s || !equalRaw(s.Raw(), tt.want) {
+			t.Errorf("Rotate(%d) = %v, expected %v in place", tt.k, s.Raw(), tt.want)
+		}
+	}
+	expectRaw(t, "Rotate empty", NewArraySlice().Rotate(3))
+}
+
+func TestArraySliceReverseRange(t *testing.T) {
+	s := NewArraySlice(1, 2, 3, 4, 5)
+	expectRaw(t, "ReverseRange(1, 4)", s.ReverseRange(1, 4), 1, 4, 3, 2, 5)
+	expectRaw(t, "ReverseRange(2, 2)", s.ReverseRange(2, 2), 1, 4, 3, 2, 5)
+	expectRaw(t, "ReverseRange(0, 5)", s.ReverseRange(0, 5), 5, 2, 3, 4, 1)
+	for _, r := range [][2]int{{-1, 2}, {3, 2}, {0, 6}} {
+		if !panics(func() { s.ReverseRange(r[0], r[1]) }) {
+			t.Errorf("expected ReverseRange(%d, %d) to panic", r[0], r[1])
+		}
+	}
+	expectRaw(t, "unchanged by panics", s, 5, 2, 3, 4, 1)
+}
+
+func TestArraySliceIntersperseAndJoin(t *testing.T) {
+	format := func(v interface{}) string { return fmt.Sprint(v) }
+	tests := []struct {
+		s            *ArraySlice
+		interspersed []interface{}
+		joined       string
+	}{
+		{NewArraySlice(), nil, ""},
+		{NewArraySlice(1), []interface{}{1}, "1"},
+		{NewArraySlice(1, 2, 3), []interface{}{1, 0, 2, 0, 3}, "1, 2, 3"},
+	}
+	for _, tt := range tests {
+		expectRaw(t, fmt.Sprintf("%v.Intersperse", tt.s.Raw()), tt.s.Intersperse(0), tt.interspersed...)
+		if got := tt.s.Join(", ", format); got != tt.joined {
+			t.Errorf("%v.Join = %q, expected %q", tt.s.Raw(), got, tt.joined)
+		}
+	}
+}
+
+func TestArraySliceAggregates(t *testing.T) {
+	type item struct {
+		name  string
+		price float64
+	}
+	price := func(v interface{}) float64 { return v.(item).price }
+	s := NewArraySlice(item{"a", 3}, item{"b", 1}, item{"c", 5}, item{"d", 1}, item{"e", 5})
+
+	if v, ok := s.MinBy(price); !ok || v.(item).name != "b" {
+		t.Errorf("expected the first cheapest item b, got (%v, %v)", v, ok)
+	}
+	if v, ok := s.MaxBy(price); !ok || v.(item).name != "c" {
+		t.Errorf("expected the first priciest item c, got (%v, %v)", v, ok)
+	}
+	if sum := s.SumBy(price); sum != 15 {
+		t.Errorf("expected a sum of 15, got %v", sum)
+	}
+	if avg, ok := s.AverageBy(price); !ok || avg != 3 {
+		t.Errorf("expected an average of 3, got (%v, %v)", avg, ok)
+	}
+
+	empty := NewArraySlice()
+	if v, ok := empty.MinBy(price); ok || v != nil {
+		t.Errorf("expected no minimum, got (%v, %v)", v, ok)
+	}
+	if v, ok := empty.MaxBy(price); ok || v != nil {
+		t.Errorf("expected no maximum, got (%v, %v)", v, ok)
+	}
+	if sum := empty.SumBy(price); sum != 0 {
+		t.Errorf("expected an empty sum of 0, got %v", sum)
+	}
+	if avg, ok := empty.AverageBy(price); ok || avg != 0 {
+		t.Errorf("expected no average, got (%v, %v)", avg, ok)
+	}
+}
+
+func TestArraySliceIndexOfBy(t *testing.T) {
+	type tagged struct {
+		id   int
+		tags []string
+	}
+	// Pointers to equal structs are not ==, and structs holding slices can
+	// not be compared with ==.
+	sameID := func(a, b interface{}) bool { return a.(*tagged).id == b.(*tagged).id }
+	s := NewArraySlice(&tagged{1, []string{"a"}}, &tagged{2, nil}, &tagged{2, []string{"b"}})
+
+	needle := &tagged{2, []string{"other"}}
+	if i := s.IndexOfBy(needle, sameID); i != 1 {
+		t.Errorf("expected the first element with id 2 at 1, got %d", i)
+	}
+	if !s.IncludesBy(needle, sameID) {
+		t.Error("expected an element with id 2")
+	}
+	missing := &tagged{3, nil}
+	if i := s.IndexOfBy(missing, sameID); i != -1 || s.IncludesBy(missing, sameID) {
+		t.Errorf("expected no element with id 3, got index %d", i)
+	}
+	if i := NewArraySlice().IndexOfBy(needle, sameID); i != -1 {
+		t.Errorf("expected -1 in an empty slice, got %d", i)
+	}
+}
+
+func TestArraySliceReduceWhile(t *testing.T) {
+	s := NewArraySlice(3, 4, 5, 6)
+	var visited []interface{}
+	// Accumulate within a budget of 10, stopping with the sum which exceeds it.
+	budget := func(acc, cur interface{}, index int) (interface{}, bool) {
+		visited = append(visited, index)
+		sum := acc.(int) + cur.(int)
+		return sum, sum <= 10
+	}
+	if got := s.ReduceWhile(budget, 0); got != 12 {
+		t.Errorf("expected to stop at 12, got %v", got)
+	}
+	if !equalRaw(visited, []interface{}{0, 1, 2}) {
+		t.Errorf("expected the last element not to be visited, got %v", visited)
+	}
+
+	always := func(acc, cur interface{}, index int) (interface{}, bool) {
+		return add(acc, cur, index), true
+	}
+	if got, want := s.ReduceWhile(always, 0), s.Reduce(add, 0); got != want {
+		t.Errorf("expected the same result as Reduce %v, got %v", want, got)
+	}
+	if got := NewArraySlice().ReduceWhile(budget, 7); got != 7 {
+		t.Errorf("expected the initial value for an empty slice, got %v", got)
+	}
+}
+
+func TestArraySliceCopyWithin(t *testing.T) {
+	tests := []struct {
+		target, start, end int
+		want               []interface{}
+	}{
+		{0, 3, 5, []interface{}{3, 4, 2, 3, 4}},
+		// Overlapping ranges, copying forward and backward.
+		{1, 0, 3, []interface{}{0, 0, 1, 2, 4}},
+		{0, 1, 4, []interface{}{1, 2, 3, 3, 4}},
+		// Negative indexes count back from the end.
+		{-2, 0, 2, []interface{}{0, 1, 2, 0, 1}},
+		{0, -2, -1, []interface{}{3, 1, 2, 3, 4}},
+		// The size is kept, and out of range indexes are clamped.
+		{3, 0, 10, []interface{}{0, 1, 2, 0, 1}},
+		{10, 0, 2, []interface{}{0, 1, 2, 3, 4}},
+		{0, 3, 1, []interface{}{0, 1, 2, 3, 4}},
+	}
+	for _, tt := range tests {
+		s := NewArraySlice(0, 1, 2, 3, 4)
+		if got := s.CopyWithin(tt.target, tt.start, tt.end); got != s || !equalRaw(s.Raw(), tt.want) {
+			t.Errorf("CopyWithin(%d, %d, %d) = %v, expected %v in place",
+				tt.target, tt.start, tt.end, s.Raw(), tt.want)
+		}
+	}
+}
+
+func TestArraySliceFill(t *testing.T) {
+	tests := []struct {
+		start, end int
+		want       []interface{}
+	}{
+		{0, 5, []interface{}{9, 9, 9, 9, 9}},
+		{1, 3, []interface{}{0, 9, 9, 3, 4}},
+		{-2, 5, []interface{}{0, 1, 2, 9, 9}},
+		{-4, -2, []interface{}{0, 9, 9, 3, 4}},
+		{-10, 1, []interface{}{9, 1, 2, 3, 4}},
+		{3, 10, []interface{}{0, 1, 2, 9, 9}},
+		{3, 1, []interface{}{0, 1, 2, 3, 4}},
+	}
+	for _, tt := range tests {
+		s := NewArraySlice(0, 1, 2, 3, 4)
+		if got := s.Fill(9, tt.start, tt.end); got != s || !equalRaw(s.Raw(), tt.want) {
+			t.Errorf("Fill(9, %d, %d) = %v, expected %v in place", tt.start, tt.end, s.Raw(), tt.want)
+		}
+	}
+}
+
+func TestArraySliceTap(t *testing.T) {
+	s := NewArraySlice(1, 2, 3, 4)
+	var sizes []interface{}
+	var each []interface{}
+	double := func(v interface{}) interface{} { return v.(int) * 2 }
+	result := s.Filter(func(v interface{}) bool { return v.(int) > 1 }).
+		Tap(func(s Slice) { sizes = append(sizes, s.Size()) }).
+		Map(double).
+		TapEach(func(v interface{}) { each = append(each, v) })
+	expectRaw(t, "chain", result, 4, 6, 8)
+	if !equalRaw(sizes, []interface{}{3}) || !equalRaw(each, []interface{}{4, 6, 8}) {
+		t.Errorf("expected the side effects to run once, got %v and %v", sizes, each)
+	}
+
+	var tapped Slice
+	if got := s.Tap(func(s Slice) { tapped = s }); got != s || tapped != s {
+		t.Error("expected Tap to pass and return the identical slice")
+	}
+	if got := s.TapEach(func(interface{}) {}); got != s {
+		t.Error("expected TapEach to return the identical slice")
+	}
+	expectRaw(t, "unchanged", s, 1, 2, 3, 4)
+}
+
+func TestArraySliceFilterMap(t *testing.T) {
+	values := make([]interface{}, 1000)
+	for i := range values {
+		values[i] = i
+	}
+	s := NewArraySlice(values...)
+
+	calls := 0
+	squareOfEven := func(v interface{}) (interface{}, bool) {
+		calls++
+		n := v.(int)
+		return n * n, n%2 == 0
+	}
+	got := s.FilterMap(squareOfEven)
+	if calls != len(values) {
+		t.Errorf("expected a single pass of %d calls, got %d", len(values), calls)
+	}
+
+	even := func(v interface{}) bool { return v.(int)%2 == 0 }
+	square := func(v interface{}) interface{} { return v.(int) * v.(int) }
+	if want := s.Filter(even).Map(square); !equalRaw(got.Raw(), want.Raw()) {
+		t.Errorf("expected the same result as Filter then Map, got %v", got.Raw()[:5])
+	}
+	if got.Size() != 500 {
+		t.Errorf("expected 500 elements, got %d", got.Size())
+	}
+}
+
+func TestArraySliceOfType(t *testing.T) {
+	type id int
+	s := NewArraySlice(1, "a", 2.5, 3, id(4), "b", nil, 5)
+	expectRaw(t, "OfType int", s.OfType(0), 1, 3, 5)
+	expectRaw(t, "OfType string", s.OfType(""), "a", "b")
+	expectRaw(t, "OfType named type", s.OfType(id(0)), id(4))
+	expectRaw(t, "OfType nil", s.OfType(nil), nil)
+	expectRaw(t, "OfTypeName int", s.OfTypeName("int"), 1, 3, 5)
+	expectRaw(t, "OfTypeName named type", s.OfTypeName("gods.id"), id(4))
+	expectRaw(t, "OfTypeName unknown", s.OfTypeName("bool"))
+}
+
+func TestArraySliceEqualSlice(t *testing.T) {
+	eq := func(a, b interface{}) bool { return a == b }
+	s := NewArraySlice(1, 2, 3)
+	tests := []struct {
+		other Slice
+		want  bool
+	}{
+		{NewArraySlice(1, 2, 3), true},
+		{NewArraySlice(1, 2), false},
+		{NewArraySlice(1, 2, 3, 4), false},
+		{NewArraySlice(1, 5, 3), false},
+	}
+	for _, tt := range tests {
+		if got := s.EqualSlice(tt.other, eq); got != tt.want {
+			t.Errorf("EqualSlice(%v) = %v, expected %v", tt.other.Raw(), got, tt.want)
+		}
+	}
+	if !NewArraySlice().EqualSlice(NewArraySlice(), eq) {
+		t.Error("expected empty slices to be equal")
+	}
+
+	nested := NewArraySlice([]int{1}, map[string]int{"a": 1})
+	if !nested.DeepEqualSlice(NewArraySlice([]int{1}, map[string]int{"a": 1})) {
+		t.Error("expected deeply equal slices to be equal")
+	}
+	if nested.DeepEqualSlice(NewArraySlice([]int{1}, map[string]int{"a": 2})) {
+		t.Error("expected a differing nested element to be detected")
+	}
+	if nested.DeepEqualSlice(NewArraySlice([]int{1})) {
+		t.Error("expected slices of different sizes to differ")
+	}
+}
+
+func TestArraySliceToMap(t *testing.T) {
+	type user struct {
+		id   int
+		name string
+	}
+	alice, bob, carol := user{1, "alice"}, user{2, "bob"}, user{1, "carol"}
+	s := NewArraySlice(alice, bob, carol)
+	byID := func(v interface{}) interface{} { return v.(user).id }
+
+	m := s.ToMap(byID)
+	if m.Size() != 2 {
+		t.Errorf("expected 2 distinct ids, got %d", m.Size())
+	}
+	// carol collides with alice on id 1, and wins as the last one.
+	if v, _ := m.Get(1); v != carol {
+		t.Errorf("expected carol bound to 1, got %v", v)
+	}
+	if v, _ := m.Get(2); v != bob {
+		t.Errorf("expected bob bound to 2, got %v", v)
+	}
+
+	names := s.ToMapBy(byID, func(v interface{}) interface{} { return v.(user).name })
+	if v, _ := names.Get(1); v != "carol" || names.Size() != 2 {
+		t.Errorf("expected carol's name bound to 1, got %v", v)
+	}
+	if v, _ := names.Get(2); v != "bob" {
+		t.Errorf("expected bob's name bound to 2, got %v", v)
+	}
+}
+
+func TestArraySliceSplitAt(t *testing.T) {
+	s := NewArraySlice(1, 2, 3)
+	tests := []struct {
+		index       int
+		left, right []interface{}
+	}{
+		{0, nil, []interface{}{1, 2, 3}},
+		{1, []interface{}{1}, []interface{}{2, 3}},
+		{3, []interface{}{1, 2, 3}, nil},
+		{-1, nil, []interface{}{1, 2, 3}},
+		{10, []interface{}{1, 2, 3}, nil},
+	}
+	for _, tt := range tests {
+		left, right := s.SplitAt(tt.index)
+		if !equalRaw(left.Raw(), tt.left) || !equalRaw(right.Raw(), tt.right) {
+			t.Errorf("SplitAt(%d) = %v %v, expected %v %v", tt.index, left.Raw(), right.Raw(), tt.left, tt.right)
+		}
+	}
+	left, _ := s.SplitAt(2)
+	left.Append(9)
+	expectRaw(t, "unchanged", s, 1, 2, 3)
+}
+
+func TestArraySliceSplitBy(t *testing.T) {
+	isZero := func(v interface{}) bool { return v == 0 }
+	tests := []struct {
+		s    *ArraySlice
+		want string
+	}{
+		{NewArraySlice(1, 2, 0, 3), "[[1 2] [3]]"},
+		{NewArraySlice(1, 0, 0, 2), "[[1] [] [2]]"},
+		{NewArraySlice(0, 1, 0, 0), "[[] [1] [] []]"},
+		{NewArraySlice(1, 2), "[[1 2]]"},
+		{NewArraySlice(0), "[[] []]"},
+		{NewArraySlice(), "[[]]"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprint(nestedRaw(tt.s.SplitBy(isZero))); got != tt.want {
+			t.Errorf("%v.SplitBy = %s, expected %s", tt.s.Raw(), got, tt.want)
+		}
+	}
+}
+
+func TestArraySliceReversed(t *testing.T) {
+	s := NewArraySlice(1, 2, 3)
+	reversed := s.Reversed()
+	expectRaw(t, "Reversed", reversed, 3, 2, 1)
+	expectRaw(t, "Reversed leaves the source unchanged", s, 1, 2, 3)
+	reversed.Append(0)
+	expectRaw(t, "Reversed is a copy", s, 1, 2, 3)
+
+	if got := s.Reverse(); got != s {
+		t.Error("expected Reverse to return the slice itself")
+	}
+	expectRaw(t, "Reverse mutates the source", s, 3, 2, 1)
+	expectRaw(t, "Reversed empty", NewArraySlice().Reversed())
+}
+
+func TestArraySliceDedup(t *testing.T) {
+	tests := []struct {
+		s    *ArraySlice
+		want []interface{}
+	}{
+		{NewArraySlice(1, 1, 2, 2, 2, 1, 3, 3), []interface{}{1, 2, 1, 3}},
+		{NewArraySlice(1, 2, 3), []interface{}{1, 2, 3}},
+		{NewArraySlice(4, 4, 4), []interface{}{4}},
+		{NewArraySlice(), nil},
+	}
+	for _, tt := range tests {
+		expectRaw(t, fmt.Sprintf("%v.Dedup", tt.s.Raw()), tt.s.Dedup(), tt.want...)
+	}
+
+	// The first of each run is kept.
+	sameFold := func(a, b interface{}) bool { return strings.EqualFold(a.(string), b.(string)) }
+	s := NewArraySlice("a", "A", "b", "B", "b", "a")
+	expectRaw(t, "DedupBy", s.DedupBy(sameFold), "a", "b", "a")
+	expectRaw(t, "unchanged", s, "a", "A", "b", "B", "b", "a")
+}
+
+func TestArraySliceSetOperations(t *testing.T) {
+	tests := []struct {
+		name                       string
+		a, b                       *ArraySlice
+		union, intersect, subtract []interface{}
+	}{
+		{
+			"overlapping",
+			NewArraySlice(1, 2, 3, 4), NewArraySlice(3, 4, 5),
+			[]interface{}{1, 2, 3, 4, 5}, []interface{}{3, 4}, []interface{}{1, 2},
+		},
+		{
+			"disjoint",
+			NewArraySlice(1, 2), NewArraySlice(3, 4),
+			[]interface{}{1, 2, 3, 4}, nil, []interface{}{1, 2},
+		},
+		{
+			"internal duplicates",
+			NewArraySlice(2, 1, 2, 3, 1), NewArraySlice(1, 1, 4, 2),
+			[]interface{}{2, 1, 3, 4}, []interface{}{2, 1}, []interface{}{3},
+		},
+		{
+			"duplicates kept by subtract",
+			NewArraySlice(5, 1, 5, 2), NewArraySlice(1),
+			[]interface{}{5, 1, 2}, []interface{}{1}, []interface{}{5, 5, 2},
+		},
+		{
+			"empty",
+			NewArraySlice(), NewArraySlice(1),
+			[]interface{}{1}, nil, nil,
+		},
+	}
+	for _, tt := range tests {
+		expectRaw(t, tt.name+" Union", tt.a.Union(tt.b), tt.union...)
+		expectRaw(t, tt.name+" Intersect", tt.a.Intersect(tt.b), tt.intersect...)
+		expectRaw(t, tt.name+" SubtractSlice", tt.a.SubtractSlice(tt.b), tt.subtract...)
+	}
+
+	if !panics(func() { NewArraySlice([]int{1}).Union(NewArraySlice()) }) {
+		t.Error("expected a non comparable element to panic")
+	}
+}
+
+func TestArraySliceReduceLeaves(t *testing.T) {
+	sum := func(acc, leaf interface{}) interface{} { return acc.(int) + leaf.(int) }
+	nested := NewArraySlice(1, NewArraySlice(2, []interface{}{3, NewArraySlice()}), []interface{}{}, 4)
+	if got := nested.ReduceLeaves(sum, 0); got != 10 {
+		t.Errorf("expected the leaves to sum to 10, got %v", got)
+	}
+
+	var order []interface{}
+	nested.ReduceLeaves(func(acc, leaf interface{}) interface{} {
+		order = append(order, leaf)
+		return acc
+	}, nil)
+	if !equalRaw(order, []interface{}{1, 2, 3, 4}) {
+		t.Errorf("expected the leaves from left to right, got %v", order)
+	}
+
+	flat := NewArraySlice(5, 6, 7)
+	if got, want := flat.ReduceLeaves(sum, 0), flat.Reduce(add, 0); got != want {
+		t.Errorf("expected the same result as Reduce %v on a flat slice, got %v", want, got)
+	}
+}
+
+func TestArraySliceIndexed(t *testing.T) {
+	s := NewArraySlice("a", "b", "c", "d", "e")
+	prefix := func(i int, v interface{}) interface{} { return fmt.Sprint(i, ".", v) }
+	expectRaw(t, "MapIndexed", s.MapIndexed(prefix), "0.a", "1.b", "2.c", "3.d", "4.e")
+	everyOther := func(i int, _ interface{}) bool { return i%2 == 0 }
+	expectRaw(t, "FilterIndexed", s.FilterIndexed(everyOther), "a", "c", "e")
+	expectRaw(t, "unchanged", s, "a", "b", "c", "d", "e")
+}
+
+func TestArraySliceInsertSorted(t *testing.T) {
+	s := NewArraySlice()
+	for _, v := range []int{5, 1, 9, 3, 7, 0, 10} {
+		if got := s.InsertSorted(v, intCompare); got != s {
+			t.Fatal("expected InsertSorted to return the slice itself")
+		}
+	}
+	expectRaw(t, "InsertSorted", s, 0, 1, 3, 5, 7, 9, 10)
+
+	// Equal elements are inserted after the present ones.
+	type pair struct{ key, tag int }
+	byKey := func(a, b interface{}) int { return intCompare(a.(pair).key, b.(pair).key) }
+	p := NewArraySlice(pair{1, 0}, pair{2, 0})
+	p.InsertSorted(pair{1, 1}, byKey)
+	expectRaw(t, "InsertSorted after equals", p, pair{1, 0}, pair{1, 1}, pair{2, 0})
+}
+
+func TestArraySliceSearchSorted(t *testing.T) {
+	s := NewArraySlice(1, 3, 3, 5)
+	tests := []struct {
+		value, index int
+		found        bool
+	}{
+		{1, 0, true},
+		{3, 1, true},
+		{5, 3, true},
+		{0, 0, false},
+		{4, 3, false},
+		{6, 4, false},
+	}
+	for _, tt := range tests {
+		if index, found := s.SearchSorted(tt.value, intCompare); index != tt.index || found != tt.found {
+			t.Errorf("SearchSorted(%d) = (%d, %v), expected (%d, %v)", tt.value, index, found, tt.index, tt.found)
+		}
+	}
+	if index, found := NewArraySlice().SearchSorted(1, intCompare); index != 0 || found {
+		t.Errorf("expected (0, false) in an empty slice, got (%d, %v)", index, found)
+	}
+}
@@ -0,0 +1,28 @@
+package gods
+
+// IsBalanced checks whether the brackets in s are properly matched and
+// nested, according to pairs mapping each opening bracket to its closing
+// one, e.g. {'(': ')', '[': ']'}. Other runes are ignored. A pair may use
+// the same rune to open and close, like quotes.
+func IsBalanced(s string, pairs map[rune]rune) bool {
+	openers := make(map[rune]rune, len(pairs))
+	for open, close := range pairs {
+		openers[close] = open
+	}
+
+	var stack []rune
+	for _, r := range s {
+		if open, isCloser := openers[r]; isCloser && len(stack) > 0 && stack[len(stack)-1] == open {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		if _, isOpener := pairs[r]; isOpener {
+			stack = append(stack, r)
+			continue
+		}
+		if _, isCloser := openers[r]; isCloser {
+			return false
+		}
+	}
+	return len(stack) == 0
+}
@@ -0,0 +1,53 @@
+package gods
+
+import "testing"
+
+func TestIsBalanced(t *testing.T) {
+	brackets := map[rune]rune{'(': ')', '[': ']', '{': '}'}
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{"", true},
+		{"no brackets", true},
+		{"()", true},
+		{"([]{})", true},
+		{"f(a[0], {b: (c)})", true},
+		{"((([[[{{{}}}]]])))", true},
+		{"([)]", false},
+		{"{(})", false},
+		{")", false},
+		{"())", false},
+		{"a]", false},
+		{"(", false},
+		{"(()", false},
+		{"{[]", false},
+	}
+	for _, tt := range tests {
+		if got := IsBalanced(tt.s, brackets); got != tt.want {
+			t.Errorf("IsBalanced(%q) = %v, expected %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestIsBalancedCustomPairs(t *testing.T) {
+	pairs := map[rune]rune{'<': '>', '«': '»', '"': '"'}
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{"<a «b» \"c\">", true},
+		{"\"<>\"", true},
+		{"<\">\"", false},
+		{"«<»>", false},
+		{"\"", false},
+		// Brackets outside of the pairs are ignored.
+		{"(<]", false},
+		{"(<>]", true},
+	}
+	for _, tt := range tests {
+		if got := IsBalanced(tt.s, pairs); got != tt.want {
+			t.Errorf("IsBalanced(%q) = %v, expected %v", tt.s, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package gods
+
+import (
+	"context"
+	"sync"
+)
+
+// BlockingQueue is a bounded first-in-first-out Container safe for
+// concurrent use, which provides backpressure like a buffered channel:
+// Put blocks while the BlockingQueue is full and Take blocks while it is
+// empty.
+type BlockingQueue struct {
+	mu       sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	items    []interface{}
+	head     int
+	size     int
+}
+
+// NewBlockingQueue creates an empty BlockingQueue holding at most capacity
+// elements. It panics if capacity is not positive.
+func NewBlockingQueue(capacity int) *BlockingQueue {
+	if capacity <= 0 {
+		panic("gods: BlockingQueue capacity must be positive")
+	}
+	q := &BlockingQueue{items: make([]interface{}, capacity)}
+	q.notEmpty = sync.NewCond(&q.mu)
+	q.notFull = sync.NewCond(&q.mu)
+	return q
+}
+
+// Empty indicates if the BlockingQueue is empty.
+func (q *BlockingQueue) Empty() bool {
+	return q.Size() == 0
+}
+
+// Size retrieves BlockingQueue size.
+func (q *BlockingQueue) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.size
+}
+
+// Cap retrieves the maximum number of elements the BlockingQueue holds.
+func (q *BlockingQueue) Cap() int {
+	return len(q.items)
+}
+
+// Clear resets BlockingQueue, it will be empty with size 0. Blocked
+// producers are woken up.
+func (q *BlockingQueue) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i := range q.items {
+		q.items[i] = nil
+	}
+	q.head, q.size = 0, 0
+	q.notFull.Broadcast()
+}
+
+// Peek inspects the start element of BlockingQueue without removing it.
+// Returns (nil, false) if the BlockingQueue is empty.
+func (q *BlockingQueue) Peek() (interface{}, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.size == 0 {
+		return nil, false
+	}
+	return q.items[q.head], true
+}
+
+// PeekAt inspects the element at index, 0 being the start, without removing
+// it. Returns (nil, false) if index is out of the [0, Size) range.
+func (q *BlockingQueue) PeekAt(index int) (interface{}, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if index < 0 || index >= q.size {
+		return nil, false
+	}
+	return q.items[(q.head+index)%len(q.items)], true
+}
+
+// Put appends an element to the end of BlockingQueue, waiting for room if
+// the BlockingQueue is full.
+func (q *BlockingQueue) Put(v interface{}) {
+	_ = q.PutContext(context.Background(), v)
+}
+
+// PutContext is like Put, but gives up and returns ctx.Err() if ctx is
+// done before there is room for the element.
+func (q *BlockingQueue) PutContext(ctx context.Context, v interface{}) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.size == len(q.items) {
+		defer q.wakeOnDone(ctx, q.notFull)()
+		for q.size == len(q.items) {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			q.notFull.Wait()
+		}
+	}
+	q.items[(q.head+q.size)%len(q.items)] = v
+	q.size++
+	q.notEmpty.Signal()
+	return nil
+}
+
+// Take ejects the start element of BlockingQueue and removes it, waiting
+// for an element if the BlockingQueue is empty.
+func (q *BlockingQueue) Take() interface{} {
+	v, _ := q.TakeContext(context.Background())
+	return v
+}
+
+// TakeContext is like Take, but gives up and returns (nil, ctx.Err()) if
+// ctx is done before an element is available.
+func (q *BlockingQueue) TakeContext(ctx context.Context) (interface{}, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.size == 0 {
+		defer q.wakeOnDone(ctx, q.notEmpty)()
+		for q.size == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			q.notEmpty.Wait()
+		}
+	}
+	v := q.items[q.head]
+	q.items[q.head] = nil
+	q.head = (q.head + 1) % len(q.items)
+	q.size--
+	q.notFull.Signal()
+	return v, nil
+}
+
+// wakeOnDone wakes up all waiters of cond once ctx is done, so that they can
+// notice the cancellation. The returned function must be called to release
+// the watcher once waiting is over.
+func (q *BlockingQueue) wakeOnDone(ctx context.Context, cond *sync.Cond) (stop func()) {
+	if ctx.Done() == nil {
+		return func() {}
+	}
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			q.mu.Lock()
+			cond.Broadcast()
+			q.mu.Unlock()
+		case <-done:
+		}
+	}()
+	return func() { close(done) }
+}
+
+// Snapshot atomically returns an ArraySlice with a copy of all the elements
+// of BlockingQueue, from the start to the end, without modifying the
+// BlockingQueue.
+func (q *BlockingQueue) Snapshot() Slice {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	values := make([]interface{}, q.size)
+	for i := range values {
+		values[i] = q.items[(q.head+i)%len(q.items)]
+	}
+	return &ArraySlice{raw: values}
+}
+
+// Each iterates a Snapshot of BlockingQueue from the start to the end, so fn
+// may safely call methods of the BlockingQueue.
+// Stop iterating if the IndexRangerFunc returns false.
+func (q *BlockingQueue) Each(fn IndexRangerFunc) {
+	q.Snapshot().RangeWithIndex(fn)
+}
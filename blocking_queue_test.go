@@ -0,0 +1,166 @@
+package gods
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBlockingQueue(t *testing.T) {
+	q := NewBlockingQueue(2)
+	if !q.Empty() || q.Cap() != 2 {
+		t.Fatal("expected new queue to be empty with capacity 2")
+	}
+	q.Put(1)
+	q.Put(2)
+	if v, ok := q.Peek(); !ok || v != 1 {
+		t.Errorf("expected Peek to return 1, got %v", v)
+	}
+	if v := q.Take(); v != 1 {
+		t.Errorf("expected 1, got %v", v)
+	}
+	q.Put(3)
+	if v := q.Take(); v != 2 {
+		t.Errorf("expected 2, got %v", v)
+	}
+	if v := q.Take(); v != 3 {
+		t.Errorf("expected 3, got %v", v)
+	}
+	q.Put(4)
+	q.Clear()
+	if !q.Empty() {
+		t.Error("expected queue to be empty after Clear")
+	}
+}
+
+func TestBlockingQueuePutBlocksWhenFull(t *testing.T) {
+	q := NewBlockingQueue(1)
+	q.Put(1)
+
+	put := make(chan struct{})
+	go func() {
+		q.Put(2)
+		close(put)
+	}()
+
+	select {
+	case <-put:
+		t.Fatal("expected Put to block on a full queue")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	if v := q.Take(); v != 1 {
+		t.Errorf("expected 1, got %v", v)
+	}
+	select {
+	case <-put:
+	case <-time.After(time.Second):
+		t.Fatal("expected Put to unblock after Take")
+	}
+	if v := q.Take(); v != 2 {
+		t.Errorf("expected 2, got %v", v)
+	}
+}
+
+func TestBlockingQueueTakeContext(t *testing.T) {
+	q := NewBlockingQueue(1)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	errs := make(chan error)
+	go func() {
+		_, err := q.TakeContext(ctx)
+		errs <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected TakeContext to return after cancellation")
+	}
+
+	q.Put(1)
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := q.PutContext(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if v, err := q.TakeContext(ctx); err != nil || v != 1 {
+		t.Errorf("expected available element despite done context, got %v, %v", v, err)
+	}
+}
+
+func TestBlockingQueueSnapshot(t *testing.T) {
+	q := NewBlockingQueue(3)
+	q.Put(0)
+	q.Take()
+	// Wrap around the ring buffer.
+	for i := 1; i <= 3; i++ {
+		q.Put(i)
+	}
+	snapshot := q.Snapshot()
+	if snapshot.Size() != 3 || q.Size() != 3 {
+		t.Fatal("expected Snapshot not to modify the queue")
+	}
+	for !snapshot.Empty() {
+		want, _ := snapshot.PopFront()
+		if v := q.Take(); v != want {
+			t.Errorf("expected drain order to match snapshot: %v, got %v", want, v)
+		}
+	}
+}
+
+func TestBlockingQueueEach(t *testing.T) {
+	q := NewBlockingQueue(3)
+	q.Put(0)
+	q.Take()
+	for i := 1; i <= 3; i++ {
+		q.Put(i)
+	}
+	if got := collectEach(t, q, 10); !equalRaw(got, []interface{}{1, 2, 3}) {
+		t.Errorf("expected front to back order, got %v", got)
+	}
+	if got := collectEach(t, q, 2); !equalRaw(got, []interface{}{1, 2}) {
+		t.Errorf("expected early stop, got %v", got)
+	}
+
+	// fn may use the queue without deadlocking.
+	q.Each(func(int, interface{}) bool {
+		q.Take()
+		return true
+	})
+	if !q.Empty() {
+		t.Error("expected queue to be drained from Each")
+	}
+}
+
+func TestBlockingQueuePeekAt(t *testing.T) {
+	q := NewBlockingQueue(3)
+	if _, ok := q.PeekAt(0); ok {
+		t.Error("expected PeekAt on an empty queue to fail")
+	}
+	// Wrap the ring buffer around.
+	q.Put(0)
+	q.Put(1)
+	q.Take()
+	q.Put(2)
+	q.Put(3)
+	for i, want := range []interface{}{1, 2, 3} {
+		if v, ok := q.PeekAt(i); !ok || v != want {
+			t.Errorf("expected PeekAt(%d) = %v, got %v", i, want, v)
+		}
+	}
+	for _, i := range []int{-1, 3} {
+		if v, ok := q.PeekAt(i); ok || v != nil {
+			t.Errorf("expected PeekAt(%d) to be out of range, got %v", i, v)
+		}
+	}
+	if q.Size() != 3 {
+		t.Errorf("expected PeekAt not to remove, got size %d", q.Size())
+	}
+}
@@ -0,0 +1,315 @@
+package gods
+
+import "sort"
+
+// BTree is an ordered map of keys to values kept in a B-tree of minimum
+// degree t: every node but the root holds between t-1 and 2t-1 keys, and a
+// node with k keys has k+1 children. Packing many keys in each node keeps
+// the tree shallow and its nodes cache friendly. Insert, Get and Delete run
+// in O(t log_t n).
+type BTree struct {
+	cmp    func(a, b interface{}) int
+	degree int
+	root   *bTreeNode
+	size   int
+}
+
+type bTreeNode struct {
+	keys     []interface{}
+	values   []interface{}
+	children []*bTreeNode
+}
+
+var _ KVRanger = (*BTree)(nil)
+
+// NewBTree creates an empty BTree of the given minimum degree, ordering keys
+// with cmp, which returns a negative number if a < b, zero if a == b, and a
+// positive number if a > b. Panics if degree is less than 2.
+func NewBTree(degree int, cmp func(a, b interface{}) int) *BTree {
+	if degree < 2 {
+		panic("gods: BTree degree must be at least 2")
+	}
+	return &BTree{cmp: cmp, degree: degree}
+}
+
+// Degree retrieves the minimum degree of BTree.
+func (t *BTree) Degree() int {
+	return t.degree
+}
+
+// Empty indicates if the BTree is empty.
+func (t *BTree) Empty() bool {
+	return t.size == 0
+}
+
+// Size retrieves the number of keys in the BTree.
+func (t *BTree) Size() int {
+	return t.size
+}
+
+// Clear resets BTree, it will be empty with size 0.
+func (t *BTree) Clear() {
+	t.root, t.size = nil, 0
+}
+
+// Height retrieves the number of edges from the root to the leaves, or -1
+// if the BTree is empty.
+func (t *BTree) Height() int {
+	h := -1
+	for n := t.root; n != nil; h++ {
+		if n.leaf() {
+			n = nil
+		} else {
+			n = n.children[0]
+		}
+	}
+	return h
+}
+
+// Get finds the value (if any) that is bound to a given key.
+func (t *BTree) Get(key interface{}) (interface{}, bool) {
+	for n := t.root; n != nil; {
+		i, found := t.search(n, key)
+		if found {
+			return n.values[i], true
+		}
+		if n.leaf() {
+			break
+		}
+		n = n.children[i]
+	}
+	return nil, false
+}
+
+// Has checks whether the key is in the BTree.
+func (t *BTree) Has(key interface{}) bool {
+	_, ok := t.Get(key)
+	return ok
+}
+
+// Insert binds key to value, replacing the value already bound to an equal
+// key if any.
+func (t *BTree) Insert(key, value interface{}) {
+	if t.root == nil {
+		t.root = &bTreeNode{keys: []interface{}{key}, values: []interface{}{value}}
+		t.size = 1
+		return
+	}
+	if t.full(t.root) {
+		t.root = &bTreeNode{children: []*bTreeNode{t.root}}
+		t.splitChild(t.root, 0)
+	}
+	if t.insertNonFull(t.root, key, value) {
+		t.size++
+	}
+}
+
+// Delete removes key and the value bound to it from the BTree, and reports
+// whether it was present.
+func (t *BTree) Delete(key interface{}) bool {
+	if t.root == nil {
+		return false
+	}
+	deleted := t.delete(t.root, key)
+	// Merges on the way down may empty the root, even if key is missing.
+	if len(t.root.keys) == 0 {
+		if t.root.leaf() {
+			t.root = nil
+		} else {
+			t.root = t.root.children[0]
+		}
+	}
+	if deleted {
+		t.size--
+	}
+	return deleted
+}
+
+// RangeKV iterates over BTree (key,value) pairs in ascending key order.
+// Stop iterating if the KVRangerFunc returns false.
+func (t *BTree) RangeKV(fn KVRangerFunc) {
+	if t.root != nil {
+		t.root.rangeKV(fn)
+	}
+}
+
+func (n *bTreeNode) rangeKV(fn KVRangerFunc) bool {
+	for i, key := range n.keys {
+		if !n.leaf() && !n.children[i].rangeKV(fn) {
+			return false
+		}
+		if !fn(key, n.values[i]) {
+			return false
+		}
+	}
+	return n.leaf() || n.children[len(n.keys)].rangeKV(fn)
+}
+
+func (n *bTreeNode) leaf() bool {
+	return n.children == nil
+}
+
+func (t *BTree) full(n *bTreeNode) bool {
+	return len(n.keys) == 2*t.degree-1
+}
+
+// search returns the index of the first key of n not lower than key, and
+// whether it is equal to key.
+func (t *BTree) search(n *bTreeNode, key interface{}) (int, bool) {
+	i := sort.Search(len(n.keys), func(i int) bool {
+		return mustCompare(n.keys[i], key, t.cmp) >= 0
+	})
+	return i, i < len(n.keys) && mustCompare(n.keys[i], key, t.cmp) == 0
+}
+
+// splitChild splits the full i-th child of n in two nodes of t-1 keys,
+// moving its median key up into n.
+func (t *BTree) splitChild(n *bTreeNode, i int) {
+	child := n.children[i]
+	mid := t.degree - 1
+	right := &bTreeNode{
+		keys:   append([]interface{}(nil), child.keys[mid+1:]...),
+		values: append([]interface{}(nil), child.values[mid+1:]...),
+	}
+	if !child.leaf() {
+		right.children = append([]*bTreeNode(nil), child.children[mid+1:]...)
+		child.children = child.children[:mid+1]
+	}
+	n.insertAt(i, child.keys[mid], child.values[mid])
+	n.children = append(n.children, nil)
+	copy(n.children[i+2:], n.children[i+1:])
+	n.children[i+1] = right
+	child.keys, child.values = child.keys[:mid], child.values[:mid]
+}
+
+// insertNonFull inserts in the subtree of n, which is not full, and reports
+// whether key was added rather than rebound.
+func (t *BTree) insertNonFull(n *bTreeNode, key, value interface{}) bool {
+	for {
+		i, found := t.search(n, key)
+		if found {
+			n.values[i] = value
+			return false
+		}
+		if n.leaf() {
+			n.insertAt(i, key, value)
+			return true
+		}
+		if t.full(n.children[i]) {
+			t.splitChild(n, i)
+			// The median moved up at i may be key, or precede it.
+			continue
+		}
+		n = n.children[i]
+	}
+}
+
+// delete removes key from the subtree of n, which has at least t keys
+// unless it is the root.
+func (t *BTree) delete(n *bTreeNode, key interface{}) bool {
+	i, found := t.search(n, key)
+	switch {
+	case found && n.leaf():
+		n.removeAt(i)
+		return true
+	case found:
+		// Replace key by its predecessor or successor from a child which
+		// can spare one, or merge both children around it.
+		if left := n.children[i]; len(left.keys) >= t.degree {
+			m := left.max()
+			n.keys[i], n.values[i] = m.keys[len(m.keys)-1], m.values[len(m.values)-1]
+			return t.delete(left, n.keys[i])
+		}
+		if right := n.children[i+1]; len(right.keys) >= t.degree {
+			m := right.min()
+			n.keys[i], n.values[i] = m.keys[0], m.values[0]
+			return t.delete(right, n.keys[i])
+		}
+		t.merge(n, i)
+		return t.delete(n.children[i], key)
+	case n.leaf():
+		return false
+	}
+	return t.delete(n.children[t.fill(n, i)], key)
+}
+
+// fill makes sure the i-th child of n has at least t keys before
+// descending into it, and returns the index of the child to descend into.
+func (t *BTree) fill(n *bTreeNode, i int) int {
+	child := n.children[i]
+	if len(child.keys) >= t.degree {
+		return i
+	}
+	if i > 0 && len(n.children[i-1].keys) >= t.degree {
+		// Rotate the last key of the left sibling through n.
+		left := n.children[i-1]
+		last := len(left.keys) - 1
+		child.insertAt(0, n.keys[i-1], n.values[i-1])
+		n.keys[i-1], n.values[i-1] = left.keys[last], left.values[last]
+		left.keys, left.values = left.keys[:last], left.values[:last]
+		if !left.leaf() {
+			child.children = append([]*bTreeNode{left.children[last+1]}, child.children...)
+			left.children = left.children[:last+1]
+		}
+		return i
+	}
+	if i < len(n.keys) && len(n.children[i+1].keys) >= t.degree {
+		// Rotate the first key of the right sibling through n.
+		right := n.children[i+1]
+		child.keys = append(child.keys, n.keys[i])
+		child.values = append(child.values, n.values[i])
+		n.keys[i], n.values[i] = right.keys[0], right.values[0]
+		right.removeAt(0)
+		if !right.leaf() {
+			child.children = append(child.children, right.children[0])
+			right.children = append(right.children[:0], right.children[1:]...)
+		}
+		return i
+	}
+	if i == len(n.keys) {
+		i--
+	}
+	t.merge(n, i)
+	return i
+}
+
+// merge merges the i-th key of n and its (i+1)-th child into its i-th
+// child, both of which have t-1 keys.
+func (t *BTree) merge(n *bTreeNode, i int) {
+	left, right := n.children[i], n.children[i+1]
+	left.keys = append(append(left.keys, n.keys[i]), right.keys...)
+	left.values = append(append(left.values, n.values[i]), right.values...)
+	if !left.leaf() {
+		left.children = append(left.children, right.children...)
+	}
+	n.removeAt(i)
+	n.children = append(n.children[:i+1], n.children[i+2:]...)
+}
+
+func (n *bTreeNode) insertAt(i int, key, value interface{}) {
+	n.keys = append(n.keys, nil)
+	copy(n.keys[i+1:], n.keys[i:])
+	n.keys[i] = key
+	n.values = append(n.values, nil)
+	copy(n.values[i+1:], n.values[i:])
+	n.values[i] = value
+}
+
+func (n *bTreeNode) removeAt(i int) {
+	n.keys = append(n.keys[:i], n.keys[i+1:]...)
+	n.values = append(n.values[:i], n.values[i+1:]...)
+}
+
+func (n *bTreeNode) min() *bTreeNode {
+	for !n.leaf() {
+		n = n.children[0]
+	}
+	return n
+}
+
+func (n *bTreeNode) max() *bTreeNode {
+	for !n.leaf() {
+		n = n.children[len(n.children)-1]
+	}
+	return n
+}
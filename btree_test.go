@@ -0,0 +1,151 @@
+package gods
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// checkBTree verifies the B-tree invariants of the subtree n within the
+// (lo, hi) key bounds, nil meaning unbounded, and returns its height.
+func checkBTree(t *testing.T, tree *BTree, n *bTreeNode, lo, hi interface{}) int {
+	t.Helper()
+	if n != tree.root && (len(n.keys) < tree.degree-1 || len(n.keys) > 2*tree.degree-1) {
+		t.Fatalf("expected [%d, %d] keys, got %d", tree.degree-1, 2*tree.degree-1, len(n.keys))
+	}
+	if n == tree.root && (len(n.keys) < 1 || len(n.keys) > 2*tree.degree-1) {
+		t.Fatalf("expected [1, %d] keys at the root, got %d", 2*tree.degree-1, len(n.keys))
+	}
+	if len(n.values) != len(n.keys) {
+		t.Fatalf("expected as many values as keys, got %d and %d", len(n.values), len(n.keys))
+	}
+	for i, key := range n.keys {
+		if lo != nil && key.(int) <= lo.(int) || hi != nil && key.(int) >= hi.(int) {
+			t.Fatalf("key %v out of (%v, %v)", key, lo, hi)
+		}
+		if i > 0 && n.keys[i-1].(int) >= key.(int) {
+			t.Fatalf("keys out of order: %v", n.keys)
+		}
+	}
+	if n.leaf() {
+		return 0
+	}
+	if len(n.children) != len(n.keys)+1 {
+		t.Fatalf("expected %d children, got %d", len(n.keys)+1, len(n.children))
+	}
+	height := -1
+	for i, child := range n.children {
+		clo, chi := lo, hi
+		if i > 0 {
+			clo = n.keys[i-1]
+		}
+		if i < len(n.keys) {
+			chi = n.keys[i]
+		}
+		h := checkBTree(t, tree, child, clo, chi)
+		if height >= 0 && h != height {
+			t.Fatalf("expected leaves at the same depth, got %d and %d", height, h)
+		}
+		height = h
+	}
+	return height + 1
+}
+
+func checkBTreeAgainst(t *testing.T, tree *BTree, reference map[int]int) {
+	t.Helper()
+	if tree.root != nil {
+		checkBTree(t, tree, tree.root, nil, nil)
+	}
+	if tree.Size() != len(reference) {
+		t.Fatalf("expected size %d, got %d", len(reference), tree.Size())
+	}
+	keys := make([]int, 0, len(reference))
+	for k := range reference {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	i := 0
+	tree.RangeKV(func(key, value interface{}) bool {
+		if key != keys[i] || value != reference[keys[i]] {
+			t.Fatalf("expected (%d, %d) at %d, got (%v, %v)", keys[i], reference[keys[i]], i, key, value)
+		}
+		i++
+		return true
+	})
+	if i != len(keys) {
+		t.Fatalf("expected %d pairs visited, got %d", len(keys), i)
+	}
+}
+
+func TestBTree(t *testing.T) {
+	for _, degree := range []int{2, 3, 5} {
+		tree := NewBTree(degree, intCompare)
+		if !tree.Empty() || tree.Height() != -1 || tree.Delete(1) {
+			t.Fatal("expected new tree to be empty")
+		}
+		r := rand.New(rand.NewSource(int64(degree)))
+		reference := make(map[int]int)
+		for i := 0; i < 2000; i++ {
+			key := r.Intn(500)
+			if r.Intn(3) == 0 {
+				_, present := reference[key]
+				if deleted := tree.Delete(key); deleted != present {
+					t.Fatalf("expected Delete(%d) = %v", key, present)
+				}
+				delete(reference, key)
+			} else {
+				tree.Insert(key, i)
+				reference[key] = i
+			}
+			if i%50 == 0 {
+				checkBTreeAgainst(t, tree, reference)
+			}
+		}
+		checkBTreeAgainst(t, tree, reference)
+		for key, value := range reference {
+			if v, ok := tree.Get(key); !ok || v != value {
+				t.Fatalf("expected %d bound to %d, got %v", key, value, v)
+			}
+		}
+		if tree.Has(-1) {
+			t.Error("expected -1 not to be found")
+		}
+		for key := range reference {
+			tree.Delete(key)
+			delete(reference, key)
+			checkBTreeAgainst(t, tree, reference)
+		}
+		if !tree.Empty() || tree.root != nil {
+			t.Error("expected tree to be empty after deleting all")
+		}
+	}
+}
+
+func TestBTreeHeight(t *testing.T) {
+	tree := NewBTree(16, intCompare)
+	for i := 0; i < 10000; i++ {
+		tree.Insert(i, i)
+	}
+	if h := tree.Height(); h > 3 {
+		t.Errorf("expected a shallow tree, got height %d", h)
+	}
+	count := 0
+	tree.RangeKV(func(key, value interface{}) bool {
+		count++
+		return count < 100
+	})
+	if count != 100 {
+		t.Errorf("expected RangeKV to stop after 100 pairs, got %d", count)
+	}
+	tree.Clear()
+	if !tree.Empty() {
+		t.Error("expected tree to be empty after Clear")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected degree 1 to panic")
+		}
+	}()
+	NewBTree(1, intCompare)
+}
@@ -0,0 +1,29 @@
+package gods
+
+import "sync"
+
+// CachingComparer returns a comparison function of elements which compares
+// their keys with cmp, computing the key of each distinct element once with
+// keyFn. It suits expensive keys, such as parsed ones, in a sort comparing
+// each element many times. The cache grows with every distinct element
+// compared and is never evicted, so a new CachingComparer should be created
+// per sort. The returned function is safe for concurrent use, and panics if
+// an element is not comparable, see MustBeComparable.
+func CachingComparer(cmp func(a, b interface{}) int, keyFn func(interface{}) interface{}) func(a, b interface{}) int {
+	var mu sync.Mutex
+	keys := make(map[interface{}]interface{})
+	key := func(v interface{}) interface{} {
+		MustBeComparable(v)
+		mu.Lock()
+		defer mu.Unlock()
+		k, ok := keys[v]
+		if !ok {
+			k = keyFn(v)
+			keys[v] = k
+		}
+		return k
+	}
+	return func(a, b interface{}) int {
+		return cmp(key(a), key(b))
+	}
+}
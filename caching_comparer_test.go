@@ -0,0 +1,39 @@
+package gods
+
+import (
+	"sort"
+	"strconv"
+	"testing"
+)
+
+func TestCachingComparer(t *testing.T) {
+	calls := make(map[interface{}]int)
+	parse := func(v interface{}) interface{} {
+		calls[v]++
+		n, err := strconv.Atoi(v.(string))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+	cmp := CachingComparer(intCompare, parse)
+
+	values := []interface{}{"10", "9", "100", "-1", "9", "42", "7", "10", "0", "33"}
+	sort.SliceStable(values, func(i, j int) bool { return cmp(values[i], values[j]) < 0 })
+	want := []interface{}{"-1", "0", "7", "9", "9", "10", "10", "33", "42", "100"}
+	if !equalRaw(values, want) {
+		t.Errorf("expected %v, got %v", want, values)
+	}
+	if len(calls) != 8 {
+		t.Errorf("expected keys of the 8 distinct elements, got %v", calls)
+	}
+	for v, n := range calls {
+		if n != 1 {
+			t.Errorf("expected the key of %v computed once, got %d", v, n)
+		}
+	}
+
+	if !panics(func() { cmp([]int{1}, "1") }) {
+		t.Error("expected comparing a non comparable element to panic")
+	}
+}
@@ -0,0 +1,17 @@
+package gods
+
+import "time"
+
+// Clock tells the current time to time dependent Containers, which allows
+// them to be driven by a fake Clock in tests.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// SystemClock is the Clock reading the system time.
+var SystemClock Clock = systemClock{}
@@ -0,0 +1,69 @@
+package gods
+
+// Combinations returns a Slice of all the k-element combinations of the
+// elements of s, each one a Slice preserving the order of s. There are
+// n!/(k!(n-k)!) of them for a Slice of size n. Returns an empty Slice if k
+// is negative or larger than the size of s.
+func Combinations(s Slice, k int) Slice {
+	result := s.Slice(0, 0)
+	raw := s.Raw()
+	if k < 0 || k > len(raw) {
+		return result
+	}
+
+	indexes := make([]int, k)
+	for i := range indexes {
+		indexes[i] = i
+	}
+	for {
+		combination := s.Slice(0, 0)
+		for _, i := range indexes {
+			combination.Append(raw[i])
+		}
+		result.Append(combination)
+
+		// Advance the rightmost index which has room to move, and reset
+		// the following ones right after it.
+		i := k - 1
+		for i >= 0 && indexes[i] == len(raw)-k+i {
+			i--
+		}
+		if i < 0 {
+			return result
+		}
+		indexes[i]++
+		for j := i + 1; j < k; j++ {
+			indexes[j] = indexes[j-1] + 1
+		}
+	}
+}
+
+// Permutations returns a Slice of all the orderings of the elements of s,
+// each one a Slice. There are n! of them for a Slice of size n, so it is
+// only practical for small Slices.
+func Permutations(s Slice) Slice {
+	result := s.Slice(0, 0)
+	raw := s.Raw()
+	used := make([]bool, len(raw))
+	current := make([]interface{}, 0, len(raw))
+
+	var permute func()
+	permute = func() {
+		if len(current) == len(raw) {
+			result.Append(s.Slice(0, 0).Append(current...))
+			return
+		}
+		for i, v := range raw {
+			if used[i] {
+				continue
+			}
+			used[i] = true
+			current = append(current, v)
+			permute()
+			current = current[:len(current)-1]
+			used[i] = false
+		}
+	}
+	permute()
+	return result
+}
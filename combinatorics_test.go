@@ -0,0 +1,48 @@
+package gods
+
+import (
+	"fmt"
+	"testing"
+)
+
+func rawSlices(s Slice) []string {
+	var result []string
+	s.RangeWithIndex(func(_ int, v interface{}) bool {
+		result = append(result, fmt.Sprint(v.(Slice).Raw()))
+		return true
+	})
+	return result
+}
+
+func TestCombinations(t *testing.T) {
+	got := rawSlices(Combinations(NewArraySlice(1, 2, 3, 4), 2))
+	want := []string{"[1 2]", "[1 3]", "[1 4]", "[2 3]", "[2 4]", "[3 4]"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if got := Combinations(NewArraySlice(1, 2, 3), 0); got.Size() != 1 || !got.Raw()[0].(Slice).Empty() {
+		t.Error("expected a single empty combination for k=0")
+	}
+	if got := Combinations(NewArraySlice(1, 2, 3), 3); got.Size() != 1 {
+		t.Errorf("expected a single combination for k=n, got %d", got.Size())
+	}
+	if got := Combinations(NewArraySlice(1, 2), 3); !got.Empty() {
+		t.Error("expected no combination for k>n")
+	}
+	if got := Combinations(NewArraySlice(1, 2), -1); !got.Empty() {
+		t.Error("expected no combination for negative k")
+	}
+}
+
+func TestPermutations(t *testing.T) {
+	got := rawSlices(Permutations(NewArraySlice(1, 2, 3)))
+	want := []string{"[1 2 3]", "[1 3 2]", "[2 1 3]", "[2 3 1]", "[3 1 2]", "[3 2 1]"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if got := Permutations(NewArraySlice()); got.Size() != 1 {
+		t.Errorf("expected a single empty permutation, got %d", got.Size())
+	}
+}
@@ -0,0 +1,82 @@
+package gods
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// safeCompare compares a and b with cmp. A panic raised by cmp, typically a
+// failed type assertion on mixed key types, is recovered and returned as an
+// error naming the concrete types of both operands.
+func safeCompare(a, b interface{}, cmp func(a, b interface{}) int) (result int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("gods: cannot compare %T with %T: %v", a, b, r)
+		}
+	}()
+	return cmp(a, b), nil
+}
+
+// mustCompare is like safeCompare, but panics with the descriptive error
+// instead of returning it. Ordered containers use it so that mixing
+// incompatible keys fails with a clear message.
+func mustCompare(a, b interface{}, cmp func(a, b interface{}) int) int {
+	result, err := safeCompare(a, b, cmp)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MustBeComparable panics if v can not be compared with ==, which means it
+// can not be used as a key in a hash based Container. A nil v is comparable.
+// The dynamic values of the interfaces v holds, such as interface{} struct
+// fields, are checked as well, since == panics on them too.
+func MustBeComparable(v interface{}) {
+	if v == nil {
+		return
+	}
+	mustBeComparableValue(reflect.ValueOf(v))
+}
+
+func mustBeComparableValue(v reflect.Value) {
+	t := v.Type()
+	if !t.Comparable() {
+		panic(fmt.Sprintf("gods: value of type %s is not comparable", t))
+	}
+	if !holdsInterface(t) {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Interface:
+		if !v.IsNil() {
+			mustBeComparableValue(v.Elem())
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			mustBeComparableValue(v.Field(i))
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			mustBeComparableValue(v.Index(i))
+		}
+	}
+}
+
+// holdsInterface reports whether values of the comparable type t may hold
+// an interface, whose dynamic type can not be checked statically.
+func holdsInterface(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if holdsInterface(t.Field(i).Type) {
+				return true
+			}
+		}
+	case reflect.Array:
+		return holdsInterface(t.Elem())
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package gods
+
+import (
+	"strings"
+	"testing"
+)
+
+func intCompare(a, b interface{}) int {
+	return a.(int) - b.(int)
+}
+
+func TestSafeCompare(t *testing.T) {
+	result, err := safeCompare(1, 2, intCompare)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result >= 0 {
+		t.Errorf("expected negative result, got %d", result)
+	}
+
+	_, err = safeCompare(1, "a", intCompare)
+	if err == nil {
+		t.Fatal("expected error comparing int with string")
+	}
+	if msg := err.Error(); !strings.Contains(msg, "int") || !strings.Contains(msg, "string") {
+		t.Errorf("expected error to mention both types, got %q", msg)
+	}
+}
+
+func TestMustCompare(t *testing.T) {
+	defer func() {
+		r := recover()
+		err, ok := r.(error)
+		if !ok {
+			t.Fatalf("expected error panic, got %v", r)
+		}
+		if msg := err.Error(); !strings.Contains(msg, "string") || !strings.Contains(msg, "int") {
+			t.Errorf("expected panic to mention both types, got %q", msg)
+		}
+	}()
+	mustCompare("a", 1, intCompare)
+}
+
+func TestMustBeComparable(t *testing.T) {
+	MustBeComparable(nil)
+	MustBeComparable(1)
+	MustBeComparable("a")
+	MustBeComparable(struct{ a int }{1})
+	MustBeComparable(struct{ a interface{} }{})
+	MustBeComparable(struct{ a interface{} }{1})
+	MustBeComparable([2]interface{}{"a", struct{ b interface{} }{2}})
+
+	for _, v := range []interface{}{
+		[]int{1},
+		map[int]int{},
+		struct{ a []int }{},
+		struct{ a interface{} }{[]int{1}},
+		struct{ a struct{ b interface{} } }{struct{ b interface{} }{map[int]int{}}},
+		[2]interface{}{1, func() {}},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for %T", v)
+				}
+			}()
+			MustBeComparable(v)
+		}()
+	}
+}
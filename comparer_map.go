@@ -0,0 +1,258 @@
+package gods
+
+// ComparerMap is a Map keyed by Comparers, which considers two keys the
+// same when they Compare to zero rather than when they are ==. It is backed
+// by an OrderStatisticTree, so that Add, Get, Has and Delete run in
+// O(log n).
+type ComparerMap struct {
+	tree      *OrderStatisticTree
+	nilPolicy NilPolicy
+}
+
+type comparerEntry struct {
+	key   Comparer
+	value interface{}
+}
+
+var (
+	_ Map              = (*ComparerMap)(nil)
+	_ KVRanger         = (*ComparerMap)(nil)
+	_ SortedKVIterable = (*ComparerMap)(nil)
+)
+
+func compareComparerEntries(a, b interface{}) int {
+	return a.(*comparerEntry).key.Compare(b.(*comparerEntry).key)
+}
+
+// NewComparerMap creates an empty ComparerMap.
+func NewComparerMap() *ComparerMap {
+	return NewComparerMapWithNilPolicy(AllowNil)
+}
+
+// NewComparerMapWithNilPolicy creates an empty ComparerMap handling nil keys
+// and values according to policy. A nil key is not a Comparer, so it panics
+// unless it is ignored, in which case every method handles it as absent.
+func NewComparerMapWithNilPolicy(policy NilPolicy) *ComparerMap {
+	return &ComparerMap{tree: NewOrderStatisticTree(compareComparerEntries), nilPolicy: policy}
+}
+
+// empty creates an empty ComparerMap with the same NilPolicy as ComparerMap.
+func (m *ComparerMap) empty() *ComparerMap {
+	return NewComparerMapWithNilPolicy(m.nilPolicy)
+}
+
+// lookup returns the entry to look key up with, handling a nil key like Add
+// does: it is never present if the NilPolicy ignores it, and panics
+// otherwise. Returns nil for an ignored key. Panics if key is not a
+// Comparer.
+func (m *ComparerMap) lookup(key interface{}) *comparerEntry {
+	if !m.nilPolicy.admitKey(key) {
+		return nil
+	}
+	return &comparerEntry{key: mustBeComparer(key)}
+}
+
+// find returns the entry of key, or nil, see lookup.
+func (m *ComparerMap) find(key interface{}) *comparerEntry {
+	lookup := m.lookup(key)
+	if lookup == nil {
+		return nil
+	}
+	e, ok := m.tree.Find(lookup)
+	if !ok {
+		return nil
+	}
+	return e.(*comparerEntry)
+}
+
+// Empty indicates if the ComparerMap is empty.
+func (m *ComparerMap) Empty() bool {
+	return m.tree.Empty()
+}
+
+// Size retrieves the number of (key,value) pairs of ComparerMap.
+func (m *ComparerMap) Size() int {
+	return m.tree.Size()
+}
+
+// Clear resets ComparerMap, it will be empty with size 0.
+func (m *ComparerMap) Clear() {
+	m.tree.Clear()
+}
+
+// Add adds a new (key,value) pair to the ComparerMap, mapping the new key
+// to its new value. If a key comparing to zero with key is present, it is
+// kept and rebound to value. Nothing is added if the NilPolicy ignores the
+// pair. Panics if key is not a Comparer.
+func (m *ComparerMap) Add(key, value interface{}) Map {
+	if !m.nilPolicy.admit(key, value) {
+		return m
+	}
+	if e := m.find(key); e != nil {
+		e.value = value
+		return m
+	}
+	m.tree.Insert(&comparerEntry{key: key.(Comparer), value: value})
+	return m
+}
+
+// Clone returns an independent copy of ComparerMap in O(n), with the same
+// NilPolicy and tree structure.
+func (m *ComparerMap) Clone() Map {
+	clone := m.empty()
+	// Entries are rebound in place by Add, so they are copied as well.
+	clone.tree.root = m.tree.root.clone(func(v interface{}) interface{} {
+		e := *v.(*comparerEntry)
+		return &e
+	})
+	return clone
+}
+
+// Get finds the value (if any) that is bound to a key comparing to zero
+// with key.
+func (m *ComparerMap) Get(key interface{}) (interface{}, bool) {
+	if e := m.find(key); e != nil {
+		return e.value, true
+	}
+	return nil, false
+}
+
+// Has checks whether a key comparing to zero with key is in the
+// ComparerMap.
+func (m *ComparerMap) Has(key interface{}) bool {
+	return m.find(key) != nil
+}
+
+// Delete removes the (key,value) pair whose key compares to zero with key
+// from the ComparerMap.
+func (m *ComparerMap) Delete(key interface{}) {
+	if lookup := m.lookup(key); lookup != nil {
+		m.tree.Delete(lookup)
+	}
+}
+
+// DeleteAll removes the (key,value) pairs whose key compares to zero with
+// one of keys from the ComparerMap, and returns how many were removed.
+func (m *ComparerMap) DeleteAll(keys ...interface{}) int {
+	removed := 0
+	for _, key := range keys {
+		if lookup := m.lookup(key); lookup != nil && m.tree.Delete(lookup) {
+			removed++
+		}
+	}
+	return removed
+}
+
+// ComputeIfAbsent returns the value bound to key. If key is not in the
+// ComparerMap, supplier is called once and its result is bound to key,
+// unless the NilPolicy ignores it, and returned.
+func (m *ComparerMap) ComputeIfAbsent(key interface{}, supplier func() interface{}) interface{} {
+	if e := m.find(key); e != nil {
+		return e.value
+	}
+	v := supplier()
+	if m.nilPolicy.admit(key, v) {
+		m.tree.Insert(&comparerEntry{key: key.(Comparer), value: v})
+	}
+	return v
+}
+
+// ComputeIfPresent rebinds key to the result of remap called with its
+// current value, unless the NilPolicy ignores the result. It does nothing
+// if key is not in the ComparerMap.
+func (m *ComparerMap) ComputeIfPresent(key interface{}, remap func(old interface{}) interface{}) {
+	if e := m.find(key); e != nil {
+		if v := remap(e.value); m.nilPolicy.admitValue(v) {
+			e.value = v
+		}
+	}
+}
+
+// RangeKV iterates over ComparerMap (key,value) pairs in ascending key
+// order. Stop iterating if the KVRangerFunc returns false.
+func (m *ComparerMap) RangeKV(fn KVRangerFunc) {
+	m.tree.RangeWithKey(func(v interface{}) bool {
+		e := v.(*comparerEntry)
+		return fn(e.key, e.value)
+	})
+}
+
+// SortedKVIterator returns a KVIterator over ComparerMap (key,value) pairs
+// in ascending key order, which walks the tree lazily. Modifying the
+// ComparerMap invalidates the KVIterator.
+func (m *ComparerMap) SortedKVIterator() KVIterator {
+	return &comparerMapIterator{m.tree.iterator()}
+}
+
+type comparerMapIterator struct {
+	*ostIterator
+}
+
+func (it *comparerMapIterator) Key() interface{} {
+	return it.ostIterator.Value().(*comparerEntry).key
+}
+
+func (it *comparerMapIterator) Value() interface{} {
+	return it.ostIterator.Value().(*comparerEntry).value
+}
+
+// Invert returns a new ComparerMap mapping each value to its key. If
+// several keys share the same value, the last one visited wins. Panics if a
+// value is not a Comparer.
+func (m *ComparerMap) Invert() Map {
+	return m.MapEntries(func(key, value interface{}) (interface{}, interface{}) {
+		return value, key
+	})
+}
+
+// FilterKeys returns a new ComparerMap with the (key,value) pairs whose key
+// satisfies predicate. The ComparerMap is not modified.
+func (m *ComparerMap) FilterKeys(predicate func(key interface{}) bool) Map {
+	return m.FilterEntries(func(key, _ interface{}) bool { return predicate(key) })
+}
+
+// FilterValues returns a new ComparerMap with the (key,value) pairs whose
+// value satisfies predicate. The ComparerMap is not modified.
+func (m *ComparerMap) FilterValues(predicate func(value interface{}) bool) Map {
+	return m.FilterEntries(func(_, value interface{}) bool { return predicate(value) })
+}
+
+// FilterEntries returns a new ComparerMap with the (key,value) pairs
+// satisfying predicate. The ComparerMap is not modified.
+func (m *ComparerMap) FilterEntries(predicate func(key, value interface{}) bool) Map {
+	filtered := m.empty()
+	m.RangeKV(func(key, value interface{}) bool {
+		if predicate(key, value) {
+			// Keys are visited in ascending order and are distinct.
+			filtered.tree.Insert(&comparerEntry{key: key.(Comparer), value: value})
+		}
+		return true
+	})
+	return filtered
+}
+
+// MapValues returns a new ComparerMap binding each key to its value
+// projected by transform. The ComparerMap is not modified.
+func (m *ComparerMap) MapValues(transform func(value interface{}) interface{}) Map {
+	mapped := m.empty()
+	m.RangeKV(func(key, value interface{}) bool {
+		if v := transform(value); m.nilPolicy.admitValue(v) {
+			mapped.tree.Insert(&comparerEntry{key: key.(Comparer), value: v})
+		}
+		return true
+	})
+	return mapped
+}
+
+// MapEntries returns a new ComparerMap with each (key,value) pair projected
+// by transform. If several pairs are projected to the same key, the last
+// one visited wins. The ComparerMap is not modified. Panics if a projected
+// key is not a Comparer.
+func (m *ComparerMap) MapEntries(transform func(key, value interface{}) (interface{}, interface{})) Map {
+	mapped := m.empty()
+	m.RangeKV(func(key, value interface{}) bool {
+		mapped.Add(transform(key, value))
+		return true
+	})
+	return mapped
+}
@@ -0,0 +1,101 @@
+package gods
+
+import "testing"
+
+func TestComparerMap(t *testing.T) {
+	m := NewComparerMap()
+	m.Add(caseInsensitive("abc"), 1).Add(caseInsensitive("def"), 2)
+	if v, ok := m.Get(caseInsensitive("ABC")); !ok || v != 1 {
+		t.Errorf("expected ABC to find abc, got %v", v)
+	}
+	m.Add(caseInsensitive("DEF"), 3)
+	if v, _ := m.Get(caseInsensitive("def")); v != 3 || m.Size() != 2 {
+		t.Errorf("expected def to be rebound to 3, got %v", v)
+	}
+
+	if v := m.ComputeIfAbsent(caseInsensitive("Abc"), func() interface{} { return 0 }); v != 1 {
+		t.Errorf("expected present value 1, got %v", v)
+	}
+	m.ComputeIfAbsent(caseInsensitive("ghi"), func() interface{} { return 4 })
+	m.ComputeIfPresent(caseInsensitive("GHI"), func(old interface{}) interface{} { return old.(int) + 1 })
+	if v, _ := m.Get(caseInsensitive("ghi")); v != 5 {
+		t.Errorf("expected ghi bound to 5, got %v", v)
+	}
+
+	var keys []interface{}
+	m.RangeKV(func(key, value interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+	if !equalRaw(keys, []interface{}{caseInsensitive("abc"), caseInsensitive("def"), caseInsensitive("ghi")}) {
+		t.Errorf("expected the first added keys in ascending order, got %v", keys)
+	}
+
+	large := m.FilterValues(func(value interface{}) bool { return value.(int) > 1 })
+	if large.Size() != 2 || large.Has(caseInsensitive("abc")) {
+		t.Errorf("expected 2 values > 1, got %d", large.Size())
+	}
+	doubled := m.MapValues(func(value interface{}) interface{} { return value.(int) * 2 })
+	if v, _ := doubled.Get(caseInsensitive("GHI")); v != 10 {
+		t.Errorf("expected ghi doubled to 10, got %v", v)
+	}
+	same := m.MapEntries(func(key, value interface{}) (interface{}, interface{}) {
+		return caseInsensitive("key"), value
+	})
+	if v, _ := same.Get(caseInsensitive("KEY")); v != 5 || same.Size() != 1 {
+		t.Errorf("expected the last visited pair to win, got %v", v)
+	}
+
+	m.Delete(caseInsensitive("ABC"))
+	if m.Has(caseInsensitive("abc")) || m.Size() != 2 {
+		t.Error("expected abc to be deleted")
+	}
+	m.Clear()
+	if !m.Empty() {
+		t.Error("expected map to be empty after Clear")
+	}
+}
+
+func TestComparerMapDeleteAll(t *testing.T) {
+	m := NewComparerMap()
+	m.Add(caseInsensitive("a"), 1).Add(caseInsensitive("b"), 2).Add(caseInsensitive("c"), 3)
+	if n := m.DeleteAll(caseInsensitive("A"), caseInsensitive("a"), caseInsensitive("z"), caseInsensitive("C")); n != 2 {
+		t.Errorf("expected 2 keys removed, got %d", n)
+	}
+	if m.Size() != 1 || !m.Has(caseInsensitive("b")) {
+		t.Errorf("expected only b left, got size %d", m.Size())
+	}
+}
+
+func TestComparerMapClone(t *testing.T) {
+	m := NewComparerMapWithNilPolicy(IgnoreNilValue)
+	for _, k := range []Int{5, 3, 8, 1, 4} {
+		m.Add(k, int(k)*10)
+	}
+	clone := m.Clone().(*ComparerMap)
+	if clone.nilPolicy != IgnoreNilValue {
+		t.Errorf("expected the NilPolicy to be kept, got %v", clone.nilPolicy)
+	}
+	if clone.tree.Height() != m.tree.Height() {
+		t.Errorf("expected the tree structure to be kept, got height %d and %d", clone.tree.Height(), m.tree.Height())
+	}
+
+	clone.Add(Int(3), "rebound")
+	clone.Delete(Int(8))
+	clone.Add(Int(9), 90)
+	clone.Add(Int(2), nil)
+
+	var keys, values []interface{}
+	m.RangeKV(func(key, value interface{}) bool {
+		keys = append(keys, key)
+		values = append(values, value)
+		return true
+	})
+	if !equalRaw(keys, []interface{}{Int(1), Int(3), Int(4), Int(5), Int(8)}) ||
+		!equalRaw(values, []interface{}{10, 30, 40, 50, 80}) {
+		t.Errorf("expected the original to be unchanged, got %v %v", keys, values)
+	}
+	if v, _ := clone.Get(Int(3)); v != "rebound" || clone.Size() != 5 || clone.Has(Int(2)) {
+		t.Errorf("expected the clone to be mutated, got %v with size %d", v, clone.Size())
+	}
+}
@@ -0,0 +1,140 @@
+package gods
+
+import "fmt"
+
+// compareComparers orders Comparers, panicking with a clear message if a
+// or b is not a Comparer.
+func compareComparers(a, b interface{}) int {
+	return mustBeComparer(a).Compare(mustBeComparer(b))
+}
+
+func mustBeComparer(v interface{}) Comparer {
+	c, ok := v.(Comparer)
+	if !ok {
+		panic(fmt.Sprintf("gods: %T is not a Comparer", v))
+	}
+	return c
+}
+
+// ComparerSet is a Set of Comparers, which considers two elements the same
+// when they Compare to zero rather than when they are ==. It is backed by
+// an OrderStatisticTree, so that Add, Has and Delete run in O(log n).
+type ComparerSet struct {
+	tree      *OrderStatisticTree
+	nilPolicy NilPolicy
+}
+
+var (
+	_ Set       = (*ComparerSet)(nil)
+	_ KeyRanger = (*ComparerSet)(nil)
+)
+
+// NewComparerSet creates a ComparerSet holding the given Comparers.
+func NewComparerSet(values ...Comparer) *ComparerSet {
+	return NewComparerSetWithNilPolicy(AllowNil, values...)
+}
+
+// NewComparerSetWithNilPolicy creates a ComparerSet holding the given
+// Comparers, handling nil elements like nil keys according to policy. A nil
+// element is not a Comparer, so it panics unless it is ignored, in which
+// case every method handles it as absent.
+func NewComparerSetWithNilPolicy(policy NilPolicy, values ...Comparer) *ComparerSet {
+	s := &ComparerSet{tree: NewOrderStatisticTree(compareComparers), nilPolicy: policy}
+	for _, v := range values {
+		s.Add(v)
+	}
+	return s
+}
+
+// Empty indicates if the ComparerSet is empty.
+func (s *ComparerSet) Empty() bool {
+	return s.tree.Empty()
+}
+
+// Size retrieves the number of elements in the ComparerSet.
+func (s *ComparerSet) Size() int {
+	return s.tree.Size()
+}
+
+// Clear resets ComparerSet, it will be empty with size 0.
+func (s *ComparerSet) Clear() {
+	s.tree.Clear()
+}
+
+// Add adds the elements to ComparerSet, unless an element comparing to zero
+// with them is present already, or the NilPolicy ignores them. Panics if
+// an element is not a Comparer.
+func (s *ComparerSet) Add(values ...interface{}) Set {
+	for _, v := range values {
+		if !s.nilPolicy.admitKey(v) {
+			continue
+		}
+		mustBeComparer(v)
+		if !s.tree.Has(v) {
+			s.tree.Insert(v)
+		}
+	}
+	return s
+}
+
+// Has checks whether an element comparing to zero with v is in the
+// ComparerSet.
+func (s *ComparerSet) Has(v interface{}) bool {
+	return s.nilPolicy.admitKey(v) && s.tree.Has(v)
+}
+
+// Find returns the element of the ComparerSet comparing to zero with v.
+// Returns (nil, false) if there is none.
+func (s *ComparerSet) Find(v interface{}) (interface{}, bool) {
+	if !s.nilPolicy.admitKey(v) {
+		return nil, false
+	}
+	return s.tree.Find(v)
+}
+
+// Delete removes the elements comparing to zero with the values from
+// ComparerSet, if they are present.
+func (s *ComparerSet) Delete(values ...interface{}) {
+	for _, v := range values {
+		if s.nilPolicy.admitKey(v) {
+			s.tree.Delete(v)
+		}
+	}
+}
+
+// RemoveAll removes the elements comparing to zero with the values from
+// ComparerSet, and returns how many were removed.
+func (s *ComparerSet) RemoveAll(values ...interface{}) int {
+	removed := 0
+	for _, v := range values {
+		if s.nilPolicy.admitKey(v) && s.tree.Delete(v) {
+			removed++
+		}
+	}
+	return removed
+}
+
+// RangeWithKey iterates ComparerSet elements in ascending order.
+// Stop iterating if the KeyRangerFunc returns false.
+func (s *ComparerSet) RangeWithKey(fn KeyRangerFunc) {
+	s.tree.RangeWithKey(fn)
+}
+
+// Each calls fn with every element of ComparerSet, in ascending order.
+func (s *ComparerSet) Each(fn func(value interface{})) {
+	s.tree.RangeWithKey(func(v interface{}) bool {
+		fn(v)
+		return true
+	})
+}
+
+// Count returns the number of elements of ComparerSet satisfying predicate.
+func (s *ComparerSet) Count(predicate func(value interface{}) bool) int {
+	n := 0
+	s.Each(func(v interface{}) {
+		if predicate(v) {
+			n++
+		}
+	})
+	return n
+}
@@ -0,0 +1,90 @@
+package gods
+
+import (
+	"strings"
+	"testing"
+)
+
+// caseInsensitive is a string Comparer ignoring case.
+type caseInsensitive string
+
+func (s caseInsensitive) Compare(other Comparer) int {
+	return strings.Compare(strings.ToLower(string(s)), strings.ToLower(string(other.(caseInsensitive))))
+}
+
+func TestComparerSet(t *testing.T) {
+	s := NewComparerSet(caseInsensitive("abc"), caseInsensitive("Def"))
+	if !s.Has(caseInsensitive("ABC")) || !s.Has(caseInsensitive("dEF")) {
+		t.Error("expected case-insensitive lookups to succeed")
+	}
+	if s.Has(caseInsensitive("abcd")) {
+		t.Error("expected abcd not to be found")
+	}
+
+	s.Add(caseInsensitive("ABC"), caseInsensitive("ghi"))
+	if s.Size() != 3 {
+		t.Errorf("expected ABC not to be added again, got size %d", s.Size())
+	}
+	if v, _ := s.Find(caseInsensitive("ABC")); v != caseInsensitive("abc") {
+		t.Errorf("expected the first added element to be kept, got %v", v)
+	}
+
+	var visited []interface{}
+	s.RangeWithKey(func(v interface{}) bool {
+		visited = append(visited, v)
+		return true
+	})
+	if !equalRaw(visited, []interface{}{caseInsensitive("abc"), caseInsensitive("Def"), caseInsensitive("ghi")}) {
+		t.Errorf("expected ascending order, got %v", visited)
+	}
+
+	s.Delete(caseInsensitive("DEF"), caseInsensitive("missing"))
+	if s.Has(caseInsensitive("def")) || s.Size() != 2 {
+		t.Error("expected Def to be deleted")
+	}
+	s.Clear()
+	if !s.Empty() {
+		t.Error("expected set to be empty after Clear")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected adding a non Comparer to panic")
+		}
+	}()
+	s.Add("abc")
+}
+
+func TestComparerSetRemoveAll(t *testing.T) {
+	s := NewComparerSet(caseInsensitive("a"), caseInsensitive("b"), caseInsensitive("c"))
+	if n := s.RemoveAll(caseInsensitive("B"), caseInsensitive("b"), caseInsensitive("x")); n != 1 {
+		t.Errorf("expected 1 element removed, got %d", n)
+	}
+	if s.Size() != 2 || s.Has(caseInsensitive("b")) {
+		t.Errorf("expected a and c left, got size %d", s.Size())
+	}
+}
+
+func TestComparerSetEachAndCount(t *testing.T) {
+	s := NewComparerSet(Int(3), Int(1), Int(4), Int(5), Int(9), Int(2), Int(6))
+	var visited []interface{}
+	s.Each(func(v interface{}) { visited = append(visited, v) })
+	want := []interface{}{Int(1), Int(2), Int(3), Int(4), Int(5), Int(6), Int(9)}
+	if !equalRaw(visited, want) {
+		t.Errorf("expected each element once in ascending order, got %v", visited)
+	}
+
+	even := func(v interface{}) bool { return v.(Int)%2 == 0 }
+	tally := 0
+	for _, v := range want {
+		if even(v) {
+			tally++
+		}
+	}
+	if n := s.Count(even); n != tally {
+		t.Errorf("expected %d even elements, got %d", tally, n)
+	}
+	if n := NewComparerSet().Count(even); n != 0 {
+		t.Errorf("expected no element counted in an empty set, got %d", n)
+	}
+}
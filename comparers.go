@@ -0,0 +1,69 @@
+package gods
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// Int is an int implementing Comparer, e.g. Int(5). Comparing it to
+// another type of Comparer panics.
+type Int int
+
+// Compare compares Int to other, which must be an Int.
+func (i Int) Compare(other Comparer) int {
+	switch o := other.(Int); {
+	case i < o:
+		return -1
+	case i > o:
+		return 1
+	}
+	return 0
+}
+
+// Str is a string implementing Comparer, ordered lexicographically by
+// bytes, e.g. Str("a"). Comparing it to another type of Comparer panics.
+type Str string
+
+// Compare compares Str to other, which must be a Str.
+func (s Str) Compare(other Comparer) int {
+	return strings.Compare(string(s), string(other.(Str)))
+}
+
+// Float is a float64 implementing Comparer, e.g. Float(0.5). NaN is lower
+// than any other value and equal to itself, so that Floats are totally
+// ordered. Comparing it to another type of Comparer panics.
+type Float float64
+
+// Compare compares Float to other, which must be a Float.
+func (f Float) Compare(other Comparer) int {
+	o := other.(Float)
+	switch fNaN, oNaN := math.IsNaN(float64(f)), math.IsNaN(float64(o)); {
+	case fNaN && oNaN:
+		return 0
+	case fNaN:
+		return -1
+	case oNaN:
+		return 1
+	case f < o:
+		return -1
+	case f > o:
+		return 1
+	}
+	return 0
+}
+
+// Time is a time.Time implementing Comparer, ordered chronologically, e.g.
+// Time(time.Now()). Comparing it to another type of Comparer panics.
+type Time time.Time
+
+// Compare compares Time to other, which must be a Time.
+func (t Time) Compare(other Comparer) int {
+	switch a, b := time.Time(t), time.Time(other.(Time)); {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,52 @@
+package gods
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestIntInHeap(t *testing.T) {
+	h := NewPairingHeap(compareComparers)
+	for _, v := range []Int{5, -3, 8, 0, 5, 2} {
+		h.Push(v)
+	}
+	var popped []interface{}
+	for !h.Empty() {
+		popped = append(popped, h.Pop())
+	}
+	want := []interface{}{Int(-3), Int(0), Int(2), Int(5), Int(5), Int(8)}
+	if !equalRaw(popped, want) {
+		t.Errorf("expected %v, got %v", want, popped)
+	}
+}
+
+func TestComparers(t *testing.T) {
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	nan := Float(math.NaN())
+	tests := []struct {
+		a, b Comparer
+		want int
+	}{
+		{Int(1), Int(2), -1},
+		{Int(2), Int(2), 0},
+		{Str("b"), Str("a"), 1},
+		{Str("a"), Str("ab"), -1},
+		{Float(0.5), Float(0.25), 1},
+		{Float(math.Inf(-1)), Float(-1e308), -1},
+		{nan, Float(math.Inf(-1)), -1},
+		{nan, nan, 0},
+		{Time(base), Time(base.Add(time.Nanosecond)), -1},
+		{Time(base.Add(time.Hour)), Time(base), 1},
+		// Same instant in different locations.
+		{Time(base), Time(base.In(time.FixedZone("UTC+1", 3600))), 0},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Compare(tt.b); got != tt.want {
+			t.Errorf("%v.Compare(%v) = %d, expected %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+	if !panics(func() { Int(1).Compare(Str("1")) }) {
+		t.Error("expected comparing different Comparer types to panic")
+	}
+}
@@ -0,0 +1,169 @@
+package gods
+
+import (
+	"sync/atomic"
+	"unsafe"
+)
+
+// ConcurrentQueue is an unbounded Queue safe for use by multiple producers
+// and consumers. It is a Michael-Scott linked queue: Push and Pop only
+// synchronize through atomic compare-and-swap on the head and tail, so they
+// never block each other. Its size is tracked by an atomic counter, so Size
+// and Empty are lock-free reads too.
+//
+// A ConcurrentQueue must be created with NewConcurrentQueue.
+type ConcurrentQueue struct {
+	size int64          // first field to be 64-bit aligned for atomic access
+	head unsafe.Pointer // *cqNode, always points to a dummy node
+	tail unsafe.Pointer // *cqNode
+}
+
+type cqNode struct {
+	value interface{}
+	next  unsafe.Pointer // *cqNode
+}
+
+var _ Queue = (*ConcurrentQueue)(nil)
+
+// NewConcurrentQueue creates an empty ConcurrentQueue.
+func NewConcurrentQueue() *ConcurrentQueue {
+	dummy := unsafe.Pointer(&cqNode{}) // #nosec G103
+	return &ConcurrentQueue{head: dummy, tail: dummy}
+}
+
+func loadNode(p *unsafe.Pointer) *cqNode {
+	return (*cqNode)(atomic.LoadPointer(p))
+}
+
+func casNode(p *unsafe.Pointer, old, new *cqNode) bool {
+	return atomic.CompareAndSwapPointer(p, unsafe.Pointer(old), unsafe.Pointer(new)) // #nosec G103
+}
+
+// Empty indicates if the ConcurrentQueue is empty. Under concurrent
+// modification the result is only a point-in-time estimate.
+func (q *ConcurrentQueue) Empty() bool {
+	return q.Size() == 0
+}
+
+// Size retrieves ConcurrentQueue size. Under concurrent modification the
+// result is only a point-in-time estimate.
+func (q *ConcurrentQueue) Size() int {
+	// The counter is updated right after an element is linked or unlinked,
+	// so a Pop may be counted before the matching Push.
+	if size := atomic.LoadInt64(&q.size); size > 0 {
+		return int(size)
+	}
+	return 0
+}
+
+// Clear resets ConcurrentQueue by removing all elements. Elements pushed
+// concurrently with Clear may or may not be removed.
+func (q *ConcurrentQueue) Clear() {
+	for _, ok := q.dequeue(); ok; _, ok = q.dequeue() {
+	}
+}
+
+// Peek inspects the start element of ConcurrentQueue without removing it.
+// Returns (nil, false) if the ConcurrentQueue is empty.
+func (q *ConcurrentQueue) Peek() (interface{}, bool) {
+	next := loadNode(&loadNode(&q.head).next)
+	if next == nil {
+		return nil, false
+	}
+	return next.value, true
+}
+
+// Push appends an element to the end of ConcurrentQueue.
+func (q *ConcurrentQueue) Push(v interface{}) {
+	n := &cqNode{value: v}
+	for {
+		tail := loadNode(&q.tail)
+		next := loadNode(&tail.next)
+		if tail != loadNode(&q.tail) {
+			continue
+		}
+		if next != nil {
+			// tail is lagging behind, help to swing it forward.
+			casNode(&q.tail, tail, next)
+			continue
+		}
+		if casNode(&tail.next, nil, n) {
+			atomic.AddInt64(&q.size, 1)
+			casNode(&q.tail, tail, n)
+			return
+		}
+	}
+}
+
+// Pop ejects the start element of ConcurrentQueue and removes it.
+// Returns nil without blocking if the ConcurrentQueue is empty.
+func (q *ConcurrentQueue) Pop() interface{} {
+	v, _ := q.dequeue()
+	return v
+}
+
+func (q *ConcurrentQueue) dequeue() (interface{}, bool) {
+	for {
+		head := loadNode(&q.head)
+		tail := loadNode(&q.tail)
+		next := loadNode(&head.next)
+		if head != loadNode(&q.head) {
+			continue
+		}
+		if head == tail {
+			if next == nil {
+				return nil, false
+			}
+			casNode(&q.tail, tail, next)
+			continue
+		}
+		// The value must be read before the CAS, another consumer may
+		// dequeue next as soon as it becomes the dummy node.
+		v := next.value
+		if casNode(&q.head, head, next) {
+			atomic.AddInt64(&q.size, -1)
+			return v, true
+		}
+	}
+}
+
+// TransferTo moves up to n elements from the start of ConcurrentQueue to
+// the end of dst, preserving their order, and returns the number of moved
+// elements. Each element is moved atomically, but the transfer as a whole
+// may interleave with concurrent operations.
+func (q *ConcurrentQueue) TransferTo(dst Queue, n int) int {
+	moved := 0
+	for ; moved < n; moved++ {
+		v, ok := q.dequeue()
+		if !ok {
+			break
+		}
+		dst.Push(v)
+	}
+	return moved
+}
+
+// Snapshot returns an ArraySlice with a copy of all the elements of
+// ConcurrentQueue, from the start to the end, without modifying the
+// ConcurrentQueue. It is not atomic: under concurrent modification it may
+// miss elements popped or pushed while the queue is being walked.
+func (q *ConcurrentQueue) Snapshot() Slice {
+	var values []interface{}
+	for n := loadNode(&loadNode(&q.head).next); n != nil; n = loadNode(&n.next) {
+		values = append(values, n.value)
+	}
+	return &ArraySlice{raw: values}
+}
+
+// Each iterates ConcurrentQueue from the start to the end without modifying
+// it. Under concurrent modification it may miss elements popped or pushed
+// during the iteration. Stop iterating if the IndexRangerFunc returns false.
+func (q *ConcurrentQueue) Each(fn IndexRangerFunc) {
+	i := 0
+	for n := loadNode(&loadNode(&q.head).next); n != nil; n = loadNode(&n.next) {
+		if !fn(i, n.value) {
+			return
+		}
+		i++
+	}
+}
@@ -0,0 +1,199 @@
+package gods
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestConcurrentQueue(t *testing.T) {
+	q := NewConcurrentQueue()
+	if !q.Empty() || q.Size() != 0 {
+		t.Fatal("expected new queue to be empty")
+	}
+	if v := q.Pop(); v != nil {
+		t.Errorf("expected nil from empty queue, got %v", v)
+	}
+	if _, ok := q.Peek(); ok {
+		t.Error("expected Peek on empty queue to fail")
+	}
+
+	for i := 0; i < 3; i++ {
+		q.Push(i)
+	}
+	if q.Empty() || q.Size() != 3 {
+		t.Fatalf("expected size 3, got %d", q.Size())
+	}
+	if v, ok := q.Peek(); !ok || v != 0 {
+		t.Errorf("expected Peek to return 0, got %v", v)
+	}
+	for i := 0; i < 3; i++ {
+		if v := q.Pop(); v != i {
+			t.Errorf("expected %d, got %v", i, v)
+		}
+	}
+	if !q.Empty() {
+		t.Error("expected queue to be empty after popping all")
+	}
+
+	q.Push(1)
+	q.Push(2)
+	q.Clear()
+	if !q.Empty() || q.Size() != 0 {
+		t.Error("expected queue to be empty after Clear")
+	}
+	q.Push(3)
+	if v := q.Pop(); v != 3 {
+		t.Errorf("expected 3 after Clear, got %v", v)
+	}
+}
+
+func TestConcurrentQueueStress(t *testing.T) {
+	const producers, consumers, perProducer = 8, 8, 2000
+	const total = producers * perProducer
+
+	q := NewConcurrentQueue()
+	var popped int64
+	seen := make([][]int, consumers)
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				q.Push(p*perProducer + i)
+			}
+		}(p)
+	}
+	for c := 0; c < consumers; c++ {
+		wg.Add(1)
+		go func(c int) {
+			defer wg.Done()
+			for atomic.LoadInt64(&popped) < total {
+				v := q.Pop()
+				if v == nil {
+					runtime.Gosched()
+					continue
+				}
+				atomic.AddInt64(&popped, 1)
+				seen[c] = append(seen[c], v.(int))
+			}
+		}(c)
+	}
+	wg.Wait()
+
+	counts := make([]int, total)
+	for _, values := range seen {
+		for _, v := range values {
+			counts[v]++
+		}
+	}
+	for v, n := range counts {
+		if n != 1 {
+			t.Fatalf("expected %d to be popped once, got %d", v, n)
+		}
+	}
+	if !q.Empty() {
+		t.Errorf("expected queue to be empty, got size %d", q.Size())
+	}
+}
+
+func TestConcurrentQueueTransferTo(t *testing.T) {
+	src, dst := NewConcurrentQueue(), NewConcurrentQueue()
+	for i := 0; i < 5; i++ {
+		src.Push(i)
+	}
+	dst.Push(-1)
+
+	if n := src.TransferTo(dst, 3); n != 3 {
+		t.Fatalf("expected 3 moved elements, got %d", n)
+	}
+	if n := src.TransferTo(dst, 10); n != 2 {
+		t.Fatalf("expected remaining 2 elements to be moved, got %d", n)
+	}
+	if !src.Empty() {
+		t.Error("expected source to be empty")
+	}
+	for _, want := range []int{-1, 0, 1, 2, 3, 4} {
+		if v := dst.Pop(); v != want {
+			t.Errorf("expected %d, got %v", want, v)
+		}
+	}
+}
+
+func TestConcurrentQueueSize(t *testing.T) {
+	const workers, perWorker = 16, 1000
+
+	q := NewConcurrentQueue()
+	var pushes, pops int64
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				if (w+i)%3 == 0 {
+					if q.Pop() != nil {
+						atomic.AddInt64(&pops, 1)
+					}
+				} else {
+					q.Push(i)
+					atomic.AddInt64(&pushes, 1)
+				}
+				if q.Size() < 0 {
+					t.Error("expected size never to be negative")
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if want := int(pushes - pops); q.Size() != want {
+		t.Errorf("expected size %d, got %d", want, q.Size())
+	}
+	q.Clear()
+	if q.Size() != 0 || !q.Empty() {
+		t.Errorf("expected size 0 after Clear, got %d", q.Size())
+	}
+}
+
+func TestConcurrentQueueSnapshot(t *testing.T) {
+	q := NewConcurrentQueue()
+	if !q.Snapshot().Empty() {
+		t.Error("expected empty snapshot of an empty queue")
+	}
+	for i := 0; i < 3; i++ {
+		q.Push(i)
+	}
+	snapshot := q.Snapshot()
+	if snapshot.Size() != 3 || q.Size() != 3 {
+		t.Fatal("expected Snapshot not to modify the queue")
+	}
+	for !snapshot.Empty() {
+		want, _ := snapshot.PopFront()
+		if v := q.Pop(); v != want {
+			t.Errorf("expected drain order to match snapshot: %v, got %v", want, v)
+		}
+	}
+}
+
+func TestConcurrentQueueEach(t *testing.T) {
+	q := NewConcurrentQueue()
+	if got := collectEach(t, q, 10); len(got) != 0 {
+		t.Errorf("expected no visit on an empty queue, got %v", got)
+	}
+	for i := 0; i < 3; i++ {
+		q.Push(i)
+	}
+	if got := collectEach(t, q, 10); !equalRaw(got, []interface{}{0, 1, 2}) {
+		t.Errorf("expected front to back order, got %v", got)
+	}
+	if got := collectEach(t, q, 1); !equalRaw(got, []interface{}{0}) {
+		t.Errorf("expected early stop, got %v", got)
+	}
+	if q.Size() != 3 {
+		t.Error("expected Each not to modify the queue")
+	}
+}
@@ -0,0 +1,108 @@
+package gods
+
+import (
+	"sort"
+	"strconv"
+)
+
+// ConsistentHashRing places keys on nodes by consistent hashing: nodes and
+// keys are hashed onto a ring, and a key belongs to the first node found
+// clockwise from it. Each node is placed on the ring as several virtual
+// nodes, in proportion to its weight, to even out the distribution. Adding
+// or removing a node only remaps the keys of that node.
+type ConsistentHashRing struct {
+	replicas int            // virtual nodes per unit of weight
+	weights  map[string]int // weight of each node
+	points   []ringPoint    // virtual nodes sorted by hash
+}
+
+type ringPoint struct {
+	hash uint64
+	node string
+}
+
+// NewConsistentHashRing creates an empty ConsistentHashRing placing
+// replicas virtual nodes per unit of weight of a node. Panics if replicas
+// is not positive.
+func NewConsistentHashRing(replicas int) *ConsistentHashRing {
+	if replicas <= 0 {
+		panic("gods: ConsistentHashRing replicas must be positive")
+	}
+	return &ConsistentHashRing{replicas: replicas, weights: make(map[string]int)}
+}
+
+// Empty indicates if the ConsistentHashRing has no node.
+func (r *ConsistentHashRing) Empty() bool {
+	return len(r.weights) == 0
+}
+
+// Size retrieves the number of nodes of the ConsistentHashRing.
+func (r *ConsistentHashRing) Size() int {
+	return len(r.weights)
+}
+
+// Clear removes all the nodes of the ConsistentHashRing.
+func (r *ConsistentHashRing) Clear() {
+	r.weights = make(map[string]int)
+	r.points = nil
+}
+
+// AddNode adds the node id with the given weight, or updates its weight if
+// it is already in the ConsistentHashRing. Panics if weight is not
+// positive.
+func (r *ConsistentHashRing) AddNode(id string, weight int) {
+	if weight <= 0 {
+		panic("gods: ConsistentHashRing node weight must be positive")
+	}
+	r.RemoveNode(id)
+	r.weights[id] = weight
+	for i := 0; i < weight*r.replicas; i++ {
+		r.points = append(r.points, ringPoint{
+			hash: hash64([]byte(id + "#" + strconv.Itoa(i))),
+			node: id,
+		})
+	}
+	sort.Slice(r.points, func(i, j int) bool {
+		if r.points[i].hash != r.points[j].hash {
+			return r.points[i].hash < r.points[j].hash
+		}
+		return r.points[i].node < r.points[j].node
+	})
+}
+
+// RemoveNode removes the node id, if present.
+func (r *ConsistentHashRing) RemoveNode(id string) {
+	if _, ok := r.weights[id]; !ok {
+		return
+	}
+	delete(r.weights, id)
+	points := r.points[:0]
+	for _, p := range r.points {
+		if p.node != id {
+			points = append(points, p)
+		}
+	}
+	r.points = points
+}
+
+// Weight retrieves the weight of the node id, or 0 if it is not in the
+// ConsistentHashRing.
+func (r *ConsistentHashRing) Weight(id string) int {
+	return r.weights[id]
+}
+
+// Get returns the node owning key in O(log n), or "" if the
+// ConsistentHashRing is empty.
+func (r *ConsistentHashRing) Get(key string) string {
+	if len(r.points) == 0 {
+		return ""
+	}
+	h := hash64([]byte(key))
+	i := sort.Search(len(r.points), func(i int) bool {
+		return r.points[i].hash >= h
+	})
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.points[i].node
+}
@@ -0,0 +1,86 @@
+package gods
+
+import (
+	"strconv"
+	"testing"
+)
+
+const ringKeys = 30000
+
+func ringPlacement(r *ConsistentHashRing) map[string]string {
+	placement := make(map[string]string, ringKeys)
+	for i := 0; i < ringKeys; i++ {
+		key := "key" + strconv.Itoa(i)
+		placement[key] = r.Get(key)
+	}
+	return placement
+}
+
+func TestConsistentHashRing(t *testing.T) {
+	r := NewConsistentHashRing(200)
+	if !r.Empty() || r.Get("key") != "" {
+		t.Fatal("expected new ring to be empty")
+	}
+	r.AddNode("a", 1)
+	r.AddNode("b", 1)
+	r.AddNode("c", 2)
+	if r.Size() != 3 || r.Weight("c") != 2 || r.Weight("d") != 0 {
+		t.Fatal("expected 3 nodes with their weights")
+	}
+
+	counts := make(map[string]int)
+	for _, node := range ringPlacement(r) {
+		counts[node]++
+	}
+	for node, share := range map[string]float64{"a": 0.25, "b": 0.25, "c": 0.5} {
+		if got := float64(counts[node]) / ringKeys; got < share*0.8 || got > share*1.2 {
+			t.Errorf("expected node %s to own about %.0f%% of the keys, got %.1f%%", node, 100*share, 100*got)
+		}
+	}
+
+	r.Clear()
+	if !r.Empty() || r.Get("key") != "" {
+		t.Error("expected ring to be empty after Clear")
+	}
+}
+
+func TestConsistentHashRingRemapping(t *testing.T) {
+	r := NewConsistentHashRing(200)
+	for _, node := range []string{"a", "b", "c"} {
+		r.AddNode(node, 1)
+	}
+	before := ringPlacement(r)
+
+	r.AddNode("d", 1)
+	added := ringPlacement(r)
+	moved := 0
+	for key, node := range added {
+		if node != before[key] {
+			moved++
+			if node != "d" {
+				t.Fatalf("expected %s to move to the new node, moved to %s", key, node)
+			}
+		}
+	}
+	if fraction := float64(moved) / ringKeys; fraction < 0.15 || fraction > 0.35 {
+		t.Errorf("expected about a quarter of the keys to move, got %.1f%%", 100*fraction)
+	}
+
+	r.RemoveNode("d")
+	r.RemoveNode("missing")
+	for key, node := range ringPlacement(r) {
+		if node != before[key] {
+			t.Fatalf("expected %s to return to %s after removing the node, got %s", key, before[key], node)
+		}
+	}
+
+	r.RemoveNode("b")
+	for key, node := range ringPlacement(r) {
+		if before[key] != "b" && node != before[key] {
+			t.Fatalf("expected %s not owned by the removed node to stay on %s, got %s", key, before[key], node)
+		}
+		if node == "b" {
+			t.Fatalf("expected no key on the removed node")
+		}
+	}
+}
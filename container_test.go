@@ -0,0 +1,129 @@
+package gods
+
+import (
+	"strconv"
+	"testing"
+)
+
+// containerCase drives a concrete Container through add and remove, which
+// adds an element and removes the oldest remaining one, and clear, which
+// defaults to Clear.
+type containerCase struct {
+	name   string
+	c      Container
+	add    func(i int)
+	remove func()
+	clear  func()
+}
+
+// keyed builds a containerCase for a Container whose elements are removed
+// by key, removing keys in the order they were added.
+func keyed(name string, c Container, add, del func(i int)) containerCase {
+	var keys []int
+	return containerCase{
+		name: name,
+		c:    c,
+		add: func(i int) {
+			add(i)
+			keys = append(keys, i)
+		},
+		remove: func() {
+			del(keys[0])
+			keys = keys[1:]
+		},
+		clear: func() {
+			c.Clear()
+			keys = nil
+		},
+	}
+}
+
+func concreteContainers() []containerCase {
+	blocking := NewBlockingQueue(2)
+	concurrent := NewConcurrentQueue()
+	dedup := NewDedupQueue()
+	list := NewLinkedList()
+	roundRobin := NewRoundRobinQueue(2)
+	pairing := NewPairingHeap(intCompare)
+	fibonacci := NewFibonacciHeap(intCompare)
+	heapified := Heapify(NewArraySlice(), intCompare)
+	dedupPriority := NewDedupPriorityQueue()
+	tree := NewOrderStatisticTree(intCompare)
+	btree := NewBTree(2, intCompare)
+	prefix := NewPrefixMap()
+	priority := NewPriorityCache(10)
+	ttl := NewTTLCacheWithClock(newFakeClock())
+	ring := NewConsistentHashRing(3)
+	sharded := NewShardedMap()
+	comparerMap := NewComparerMap()
+	comparerSet := NewComparerSet()
+	versioned := NewVersionedMap()
+	loading := NewLoadingCache(func(interface{}) (interface{}, error) { return nil, nil }, 10)
+	syncSet := NewSyncSet(NewComparerSet())
+	undoable := NewUndoableSlice(NewArraySlice())
+
+	return []containerCase{
+		{"BlockingQueue", blocking, func(i int) { blocking.Put(i) }, func() { blocking.Take() }, nil},
+		{"ConcurrentQueue", concurrent, func(i int) { concurrent.Push(i) }, func() { concurrent.Pop() }, nil},
+		{"DedupQueue", dedup, func(i int) { dedup.Push(i) }, func() { dedup.Pop() }, nil},
+		{"LinkedList", list, func(i int) { list.PushBack(i) }, func() { list.PopFront() }, nil},
+		{"RoundRobinQueue", roundRobin, func(i int) { roundRobin.PushTo(i%2, i) }, func() { roundRobin.Pop() }, nil},
+		{"PairingHeap", pairing, func(i int) { pairing.Push(i) }, func() { pairing.Pop() }, nil},
+		{"FibonacciHeap", fibonacci, func(i int) { fibonacci.Push(i) }, func() { fibonacci.Pop() }, nil},
+		{"Heapify", heapified, func(i int) { heapified.Push(i) }, func() { heapified.Pop() }, nil},
+		{"DedupPriorityQueue", dedupPriority, func(i int) { dedupPriority.Push(i, i, 0) }, func() { dedupPriority.Pop() }, nil},
+		{"UndoableSlice", undoable, func(i int) { undoable.Append(i) }, func() { undoable.PopFront() }, nil},
+		keyed("OrderStatisticTree", tree, func(i int) { tree.Insert(i) }, func(i int) { tree.Delete(i) }),
+		keyed("BTree", btree, func(i int) { btree.Insert(i, i) }, func(i int) { btree.Delete(i) }),
+		keyed("PrefixMap", prefix, func(i int) { prefix.Put(strconv.Itoa(i), i) }, func(i int) { prefix.Delete(strconv.Itoa(i)) }),
+		keyed("PriorityCache", priority, func(i int) { priority.Put(i, i, 1) }, func(i int) { priority.Delete(i) }),
+		keyed("TTLCache", ttl, func(i int) { ttl.Put(i, i, 0) }, func(i int) { ttl.Delete(i) }),
+		keyed("ConsistentHashRing", ring, func(i int) { ring.AddNode(strconv.Itoa(i), 1) }, func(i int) { ring.RemoveNode(strconv.Itoa(i)) }),
+		keyed("ShardedMap", sharded, func(i int) { sharded.Add(i, i) }, func(i int) { sharded.Delete(i) }),
+		keyed("ComparerMap", comparerMap, func(i int) { comparerMap.Add(Int(i), i) }, func(i int) { comparerMap.Delete(Int(i)) }),
+		keyed("ComparerSet", comparerSet, func(i int) { comparerSet.Add(Int(i)) }, func(i int) { comparerSet.Delete(Int(i)) }),
+		keyed("VersionedMap", versioned, func(i int) { versioned.Add(i, i) }, func(i int) { versioned.Delete(i) }),
+		keyed("LoadingCache", loading, func(i int) { loading.Put(i, i) }, func(i int) { loading.Delete(i) }),
+		keyed("SyncSet", syncSet, func(i int) { syncSet.Add(Int(i)) }, func(i int) { syncSet.Delete(Int(i)) }),
+	}
+}
+
+func TestContainersEmptyMatchesSize(t *testing.T) {
+	// The sequence wraps the BlockingQueue ring buffer around, never
+	// holding more than 2 elements, and clears non-empty Containers.
+	const ops = "aarar" + "raarc" + "aarrc" + "c"
+	for _, tt := range concreteContainers() {
+		t.Run(tt.name, func(t *testing.T) {
+			next, size := 0, 0
+			check := func(step int) {
+				t.Helper()
+				if got := tt.c.Size(); got != size {
+					t.Fatalf("step %d: expected size %d, got %d", step, size, got)
+				}
+				if got := tt.c.Empty(); got != (size == 0) {
+					t.Fatalf("step %d: Empty() = %v disagrees with Size() = %d", step, got, size)
+				}
+			}
+			check(-1)
+			for step, op := range ops {
+				switch op {
+				case 'a':
+					tt.add(next)
+					next++
+					size++
+				case 'r':
+					tt.remove()
+					size--
+				case 'c':
+					if tt.clear != nil {
+						tt.clear()
+					} else {
+						tt.c.Clear()
+					}
+					size = 0
+				}
+				check(step)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package gods
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+)
+
+// CountMinSketch is a probabilistic frequency table for streams too large
+// to count exactly. Estimates never undercount, and with probability at
+// least 1-delta they overcount by at most epsilon times the total count
+// added to the sketch.
+type CountMinSketch struct {
+	width  uint64
+	depth  uint64
+	counts []uint64 // depth rows of width counters
+}
+
+// NewCountMinSketch creates an empty CountMinSketch with error bound
+// epsilon and failure probability delta, using ceil(e/epsilon) counters
+// per row and ceil(ln(1/delta)) rows. Panics if epsilon or delta is not in
+// the (0, 1) range.
+func NewCountMinSketch(epsilon, delta float64) *CountMinSketch {
+	if epsilon <= 0 || epsilon >= 1 || delta <= 0 || delta >= 1 {
+		panic("gods: CountMinSketch epsilon and delta must be in (0, 1)")
+	}
+	width := uint64(math.Ceil(math.E / epsilon))
+	depth := uint64(math.Ceil(math.Log(1 / delta)))
+	return &CountMinSketch{
+		width:  width,
+		depth:  depth,
+		counts: make([]uint64, width*depth),
+	}
+}
+
+// Width retrieves the number of counters per row.
+func (s *CountMinSketch) Width() int {
+	return int(s.width)
+}
+
+// Depth retrieves the number of rows, one per hash function.
+func (s *CountMinSketch) Depth() int {
+	return int(s.depth)
+}
+
+// Add adds count occurrences of item.
+func (s *CountMinSketch) Add(item []byte, count uint64) {
+	h1, h2 := countMinHashes(item)
+	for row := uint64(0); row < s.depth; row++ {
+		s.counts[row*s.width+(h1+row*h2)%s.width] += count
+	}
+}
+
+// Estimate returns the estimated number of occurrences of item, which is
+// never lower than the exact count.
+func (s *CountMinSketch) Estimate(item []byte) uint64 {
+	h1, h2 := countMinHashes(item)
+	estimate := uint64(math.MaxUint64)
+	for row := uint64(0); row < s.depth; row++ {
+		if c := s.counts[row*s.width+(h1+row*h2)%s.width]; c < estimate {
+			estimate = c
+		}
+	}
+	return estimate
+}
+
+// countMinHashes derives two independent hashes of item, combined as
+// h1 + row*h2 to simulate one hash function per row.
+func countMinHashes(item []byte) (h1, h2 uint64) {
+	h := fnv.New128a()
+	_, _ = h.Write(item)
+	sum := h.Sum(nil)
+	h1 = binary.BigEndian.Uint64(sum[:8])
+	h2 = binary.BigEndian.Uint64(sum[8:]) | 1
+	return h1, h2
+}
@@ -0,0 +1,55 @@
+package gods
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+func TestCountMinSketch(t *testing.T) {
+	const epsilon, delta = 0.001, 0.01
+	s := NewCountMinSketch(epsilon, delta)
+	if s.Width() != 2719 || s.Depth() != 5 {
+		t.Fatalf("expected 5 rows of 2719 counters, got %d of %d", s.Depth(), s.Width())
+	}
+
+	r := rand.New(rand.NewSource(1))
+	zipf := rand.NewZipf(r, 1.2, 1, 10000)
+	exact := make(map[uint64]uint64)
+	var total uint64
+	for i := 0; i < 100000; i++ {
+		item := zipf.Uint64()
+		count := uint64(r.Intn(3) + 1)
+		s.Add([]byte(strconv.FormatUint(item, 10)), count)
+		exact[item] += count
+		total += count
+	}
+
+	bound := uint64(epsilon * float64(total))
+	for item, count := range exact {
+		estimate := s.Estimate([]byte(strconv.FormatUint(item, 10)))
+		if estimate < count {
+			t.Fatalf("estimate %d of %d is lower than the exact count %d", estimate, item, count)
+		}
+		if count >= 100 && estimate-count > bound {
+			t.Errorf("estimate %d of frequent item %d exceeds count %d by more than %d",
+				estimate, item, count, bound)
+		}
+	}
+	if estimate := s.Estimate([]byte("missing")); estimate > bound {
+		t.Errorf("expected estimate of a missing item within %d, got %d", bound, estimate)
+	}
+}
+
+func TestNewCountMinSketchPanics(t *testing.T) {
+	for _, params := range [][2]float64{{0, 0.1}, {0.1, 0}, {1, 0.1}, {0.1, 1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for epsilon=%v delta=%v", params[0], params[1])
+				}
+			}()
+			NewCountMinSketch(params[0], params[1])
+		}()
+	}
+}
@@ -0,0 +1,115 @@
+package gods
+
+// DedupPriorityQueue is a priority queue of identified elements, which pops
+// the element with the highest priority first, and elements of equal
+// priority in the order they were pushed. Pushing an ID which is already
+// queued does not add a duplicate: the queued element keeps the highest of
+// both priorities, along with its value. IDs must be comparable, see
+// MustBeComparable.
+type DedupPriorityQueue struct {
+	heap    *PairingHeap
+	handles map[interface{}]*PairingHeapNode
+	seq     uint64
+}
+
+type dedupEntry struct {
+	id, value interface{}
+	priority  float64
+	seq       uint64
+}
+
+var _ Container = (*DedupPriorityQueue)(nil)
+
+func compareDedupEntries(a, b interface{}) int {
+	x, y := a.(*dedupEntry), b.(*dedupEntry)
+	switch {
+	case x.priority > y.priority:
+		return -1
+	case x.priority < y.priority:
+		return 1
+	case x.seq < y.seq:
+		return -1
+	case x.seq > y.seq:
+		return 1
+	}
+	return 0
+}
+
+// NewDedupPriorityQueue creates an empty DedupPriorityQueue.
+func NewDedupPriorityQueue() *DedupPriorityQueue {
+	return &DedupPriorityQueue{
+		heap:    NewPairingHeap(compareDedupEntries),
+		handles: make(map[interface{}]*PairingHeapNode),
+	}
+}
+
+// Empty indicates if the DedupPriorityQueue is empty.
+func (q *DedupPriorityQueue) Empty() bool {
+	return q.heap.Empty()
+}
+
+// Size retrieves the number of queued IDs.
+func (q *DedupPriorityQueue) Size() int {
+	return q.heap.Size()
+}
+
+// Clear resets DedupPriorityQueue, it will be empty with size 0.
+func (q *DedupPriorityQueue) Clear() {
+	q.heap.Clear()
+	q.handles = make(map[interface{}]*PairingHeapNode)
+}
+
+// Push queues value under id with the given priority. If id is already
+// queued with a lower priority, its value and priority are replaced, and it
+// keeps its place among the elements of equal priority. Otherwise pushing
+// an already queued id does nothing. Panics if id is not comparable.
+func (q *DedupPriorityQueue) Push(id, value interface{}, priority float64) {
+	MustBeComparable(id)
+	if node, ok := q.handles[id]; ok {
+		if e := node.Value().(*dedupEntry); priority > e.priority {
+			q.heap.DecreaseKey(node, &dedupEntry{id: id, value: value, priority: priority, seq: e.seq})
+		}
+		return
+	}
+	q.handles[id] = q.heap.Insert(&dedupEntry{id: id, value: value, priority: priority, seq: q.seq})
+	q.seq++
+}
+
+// Peek inspects the element with the highest priority without removing it.
+// Returns (nil, nil, false) if the DedupPriorityQueue is empty.
+func (q *DedupPriorityQueue) Peek() (id, value interface{}, ok bool) {
+	v, ok := q.heap.Peek()
+	if !ok {
+		return nil, nil, false
+	}
+	e := v.(*dedupEntry)
+	return e.id, e.value, true
+}
+
+// Pop removes the element with the highest priority and returns it, so
+// that its id can be pushed again. Returns (nil, nil, false) if the
+// DedupPriorityQueue is empty.
+func (q *DedupPriorityQueue) Pop() (id, value interface{}, ok bool) {
+	if q.heap.Empty() {
+		return nil, nil, false
+	}
+	e := q.heap.Pop().(*dedupEntry)
+	delete(q.handles, e.id)
+	return e.id, e.value, true
+}
+
+// Has checks whether id is queued.
+func (q *DedupPriorityQueue) Has(id interface{}) bool {
+	_, ok := q.handles[id]
+	return ok
+}
+
+// Priority returns the priority id is queued with. Returns (0, false) if id
+// is not queued.
+func (q *DedupPriorityQueue) Priority(id interface{}) (float64, bool) {
+	node, ok := q.handles[id]
+	if !ok {
+		return 0, false
+	}
+	return node.Value().(*dedupEntry).priority, true
+}
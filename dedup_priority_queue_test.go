@@ -0,0 +1,50 @@
+package gods
+
+import "testing"
+
+func TestDedupPriorityQueue(t *testing.T) {
+	q := NewDedupPriorityQueue()
+	if _, _, ok := q.Pop(); ok {
+		t.Fatal("expected Pop on an empty queue to fail")
+	}
+
+	q.Push("a", "low", 1)
+	q.Push("b", "b", 5)
+	q.Push("a", "high", 10)
+	q.Push("b", "ignored", 2)
+	if q.Size() != 2 {
+		t.Fatalf("expected 2 queued IDs, got %d", q.Size())
+	}
+	if p, _ := q.Priority("a"); p != 10 {
+		t.Errorf("expected a raised to priority 10, got %v", p)
+	}
+	if p, _ := q.Priority("b"); p != 5 {
+		t.Errorf("expected b to keep priority 5, got %v", p)
+	}
+	if id, value, ok := q.Peek(); !ok || id != "a" || value != "high" {
+		t.Errorf("expected a with its new value first, got %v %v", id, value)
+	}
+
+	q.Push("c", "c", 5)
+	want := []struct{ id, value interface{} }{{"a", "high"}, {"b", "b"}, {"c", "c"}}
+	for _, w := range want {
+		if id, value, ok := q.Pop(); !ok || id != w.id || value != w.value {
+			t.Errorf("expected %v %v, got %v %v", w.id, w.value, id, value)
+		}
+	}
+	if !q.Empty() || q.Has("a") {
+		t.Error("expected all IDs to be popped")
+	}
+
+	q.Push("a", "again", 1)
+	if !q.Has("a") {
+		t.Error("expected a popped ID to be pushed again")
+	}
+	q.Clear()
+	if !q.Empty() || q.Has("a") {
+		t.Error("expected queue to be empty after Clear")
+	}
+	if _, ok := q.Priority("a"); ok {
+		t.Error("expected no priority for a missing ID")
+	}
+}
@@ -0,0 +1,141 @@
+package gods
+
+// DedupQueue is a Queue which ignores pushing an element that is already
+// enqueued. Once popped, an element can be enqueued again. Elements must be
+// comparable, see MustBeComparable.
+type DedupQueue struct {
+	items   []interface{}
+	pending map[interface{}]struct{}
+	// capacity is the capacity of the backing array of items, which Pop
+	// and TransferTo do not shrink though they reslice items past its start.
+	capacity int
+	onResize func(oldCap, newCap int)
+}
+
+var _ Queue = (*DedupQueue)(nil)
+
+// NewDedupQueue creates an empty DedupQueue.
+func NewDedupQueue() *DedupQueue {
+	return &DedupQueue{pending: make(map[interface{}]struct{})}
+}
+
+// Empty indicates if the DedupQueue is empty.
+func (q *DedupQueue) Empty() bool {
+	return len(q.items) == 0
+}
+
+// Size retrieves DedupQueue size.
+func (q *DedupQueue) Size() int {
+	return len(q.items)
+}
+
+// Clear resets DedupQueue, it will be empty with size 0.
+func (q *DedupQueue) Clear() {
+	q.items = nil
+	q.pending = make(map[interface{}]struct{})
+	q.resized(0)
+}
+
+// OnResize registers fn to be called after the backing array of DedupQueue
+// is reallocated by Push, or released by Clear, with its capacities before
+// and after. Push may reallocate a smaller array once elements are popped.
+// It replaces the previously registered function, and a nil fn unregisters
+// it.
+func (q *DedupQueue) OnResize(fn func(oldCap, newCap int)) {
+	q.onResize = fn
+}
+
+// resized records newCap as the capacity of the backing array, and calls
+// the OnResize function if it changed.
+func (q *DedupQueue) resized(newCap int) {
+	oldCap := q.capacity
+	q.capacity = newCap
+	if q.onResize != nil && newCap != oldCap {
+		q.onResize(oldCap, newCap)
+	}
+}
+
+// Peek inspects the start element of DedupQueue without removing it.
+// Returns (nil, false) if the DedupQueue is empty.
+func (q *DedupQueue) Peek() (interface{}, bool) {
+	if len(q.items) == 0 {
+		return nil, false
+	}
+	return q.items[0], true
+}
+
+// PeekAt inspects the element at index, 0 being the start, without removing
+// it. Returns (nil, false) if index is out of the [0, Size) range.
+func (q *DedupQueue) PeekAt(index int) (interface{}, bool) {
+	if index < 0 || index >= len(q.items) {
+		return nil, false
+	}
+	return q.items[index], true
+}
+
+// Push appends an element to the end of DedupQueue, unless it is already
+// enqueued. Panics if the element is not comparable.
+func (q *DedupQueue) Push(v interface{}) {
+	MustBeComparable(v)
+	if _, ok := q.pending[v]; ok {
+		return
+	}
+	q.pending[v] = struct{}{}
+	// append only reallocates when items is full, possibly into a smaller
+	// array than the current one if elements were popped.
+	full := len(q.items) == cap(q.items)
+	q.items = append(q.items, v)
+	if full {
+		q.resized(cap(q.items))
+	}
+}
+
+// Pop ejects the start element of DedupQueue and removes it, so that it can
+// be pushed again. Returns nil if the DedupQueue is empty.
+func (q *DedupQueue) Pop() interface{} {
+	if len(q.items) == 0 {
+		return nil
+	}
+	v := q.items[0]
+	q.items[0] = nil
+	q.items = q.items[1:]
+	delete(q.pending, v)
+	return v
+}
+
+// TransferTo moves up to n elements from the start of DedupQueue to the end
+// of dst, preserving their order, and returns the number of moved elements.
+func (q *DedupQueue) TransferTo(dst Queue, n int) int {
+	if n > len(q.items) {
+		n = len(q.items)
+	}
+	if n <= 0 {
+		return 0
+	}
+	moved := q.items[:n:n]
+	q.items = q.items[n:]
+	for _, v := range moved {
+		delete(q.pending, v)
+	}
+	for i, v := range moved {
+		moved[i] = nil
+		dst.Push(v)
+	}
+	return n
+}
+
+// Snapshot returns an ArraySlice with a copy of all the elements of
+// DedupQueue, from the start to the end, without modifying the DedupQueue.
+func (q *DedupQueue) Snapshot() Slice {
+	return NewArraySlice(q.items...)
+}
+
+// Each iterates DedupQueue from the start to the end without modifying it.
+// Stop iterating if the IndexRangerFunc returns false.
+func (q *DedupQueue) Each(fn IndexRangerFunc) {
+	for i, v := range q.items {
+		if !fn(i, v) {
+			return
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package gods
+
+import "testing"
+
+func TestDedupQueue(t *testing.T) {
+	q := NewDedupQueue()
+	if !q.Empty() || q.Pop() != nil {
+		t.Fatal("expected new queue to be empty")
+	}
+
+	q.Push("a")
+	q.Push("b")
+	q.Push("a")
+	q.Push("b")
+	if q.Size() != 2 {
+		t.Fatalf("expected duplicates of pending elements to be ignored, got size %d", q.Size())
+	}
+	if v, ok := q.Peek(); !ok || v != "a" {
+		t.Errorf("expected Peek to return a, got %v", v)
+	}
+	if v := q.Pop(); v != "a" {
+		t.Errorf("expected a, got %v", v)
+	}
+
+	q.Push("a")
+	q.Push("b")
+	if q.Size() != 2 {
+		t.Fatalf("expected popped element to be pushed again, got size %d", q.Size())
+	}
+	if v := q.Pop(); v != "b" {
+		t.Errorf("expected b, got %v", v)
+	}
+	if v := q.Pop(); v != "a" {
+		t.Errorf("expected a, got %v", v)
+	}
+
+	q.Push("c")
+	q.Clear()
+	if !q.Empty() {
+		t.Error("expected queue to be empty after Clear")
+	}
+	q.Push("c")
+	if q.Size() != 1 {
+		t.Error("expected element to be pushed again after Clear")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic pushing a non comparable element")
+		}
+	}()
+	q.Push([]int{1})
+}
+
+func TestDedupQueueTransferTo(t *testing.T) {
+	src, dst := NewDedupQueue(), NewDedupQueue()
+	for _, v := range []string{"a", "b", "c"} {
+		src.Push(v)
+	}
+	dst.Push("b")
+
+	if n := src.TransferTo(dst, 2); n != 2 {
+		t.Fatalf("expected 2 moved elements, got %d", n)
+	}
+	if n := src.TransferTo(dst, 10); n != 1 {
+		t.Fatalf("expected remaining element to be moved, got %d", n)
+	}
+	if n := src.TransferTo(dst, 1); n != 0 || !src.Empty() {
+		t.Fatalf("expected nothing to move from an empty queue, got %d", n)
+	}
+
+	// b was already pending in dst, so only a and c were enqueued.
+	for _, want := range []string{"b", "a", "c"} {
+		if v := dst.Pop(); v != want {
+			t.Errorf("expected %s, got %v", want, v)
+		}
+	}
+	src.Push("a")
+	if src.Size() != 1 {
+		t.Error("expected moved elements to be pushed again to the source")
+	}
+}
+
+func TestDedupQueueSnapshot(t *testing.T) {
+	q := NewDedupQueue()
+	if !q.Snapshot().Empty() {
+		t.Error("expected empty snapshot of an empty queue")
+	}
+	for _, v := range []string{"a", "b", "c"} {
+		q.Push(v)
+	}
+	snapshot := q.Snapshot()
+	// The snapshot is a copy, and mutating it leaves the queue unchanged.
+	snapshot.Fill("x", 0, 1)
+	if snapshot.Size() != 3 || q.Size() != 3 {
+		t.Fatal("expected Snapshot not to modify the queue")
+	}
+	for i, want := range []string{"a", "b", "c"} {
+		if v := q.Pop(); v != want {
+			t.Errorf("expected drain order to match snapshot at %d: %s, got %v", i, want, v)
+		}
+	}
+}
+
+func TestDedupQueueEach(t *testing.T) {
+	q := NewDedupQueue()
+	if got := collectEach(t, q, 10); len(got) != 0 {
+		t.Errorf("expected no visit on an empty queue, got %v", got)
+	}
+	for _, v := range []string{"a", "b", "c"} {
+		q.Push(v)
+	}
+	if got := collectEach(t, q, 10); !equalRaw(got, []interface{}{"a", "b", "c"}) {
+		t.Errorf("expected front to back order, got %v", got)
+	}
+	if got := collectEach(t, q, 2); !equalRaw(got, []interface{}{"a", "b"}) {
+		t.Errorf("expected early stop, got %v", got)
+	}
+	if q.Size() != 3 {
+		t.Error("expected Each not to modify the queue")
+	}
+}
+
+func TestDedupQueuePeekAt(t *testing.T) {
+	q := NewDedupQueue()
+	if _, ok := q.PeekAt(0); ok {
+		t.Error("expected PeekAt on an empty queue to fail")
+	}
+	q.Push("a")
+	q.Push("b")
+	q.Push("a")
+	if v, ok := q.PeekAt(1); !ok || v != "b" {
+		t.Errorf("expected PeekAt(1) = b, got %v", v)
+	}
+	for _, i := range []int{-1, 2} {
+		if v, ok := q.PeekAt(i); ok || v != nil {
+			t.Errorf("expected PeekAt(%d) to be out of range, got %v", i, v)
+		}
+	}
+}
+
+func TestDedupQueueOnResize(t *testing.T) {
+	q := NewDedupQueue()
+	var transitions [][2]int
+	q.OnResize(func(oldCap, newCap int) {
+		transitions = append(transitions, [2]int{oldCap, newCap})
+	})
+	for i := 0; i < 100; i++ {
+		q.Push(i)
+	}
+	if len(transitions) < 3 {
+		t.Fatalf("expected several growths, got %v", transitions)
+	}
+	last := 0
+	for _, tr := range transitions {
+		if tr[0] != last || tr[1] <= tr[0] {
+			t.Fatalf("expected growing capacities from %d, got %v", last, transitions)
+		}
+		last = tr[1]
+	}
+	if last < 100 {
+		t.Errorf("expected a capacity of at least 100, got %d", last)
+	}
+
+	transitions = nil
+	q.Pop()
+	q.Push(0)
+	if len(transitions) != 0 {
+		t.Errorf("expected no resize without reallocation, got %v", transitions)
+	}
+	q.Clear()
+	if len(transitions) != 1 || transitions[0][1] != 0 {
+		t.Errorf("expected Clear to release the backing array, got %v", transitions)
+	}
+
+	transitions = nil
+	for i := 0; i < 100; i++ {
+		q.Push(i)
+	}
+	full := transitions[len(transitions)-1][1]
+	for q.Size() < full {
+		q.Push(q.Size())
+	}
+	for q.Size() > 1 {
+		q.Pop()
+	}
+	transitions = nil
+	q.Push(-1)
+	if len(transitions) != 1 || transitions[0][0] != full || transitions[0][1] >= full {
+		t.Errorf("expected a shrink from the capacity %d, got %v", full, transitions)
+	}
+
+	transitions = nil
+	q.OnResize(nil)
+	q.Push(-2)
+	if len(transitions) != 0 {
+		t.Error("expected the hook to be unregistered")
+	}
+}
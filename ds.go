@@ -41,6 +41,32 @@ type KVRanger interface {
 	RangeKV(KVRangerFunc)
 }
 
+// Iterator lazily yields a sequence of elements, one at a time.
+type Iterator interface {
+	// Next advances Iterator to the next element, and reports whether
+	// there is one. It must be called before the first element.
+	Next() bool
+	// Value returns the current element.
+	Value() interface{}
+}
+
+// KVIterator is an Iterator over (key,value) pairs, whose Value is the
+// value of the current pair.
+type KVIterator interface {
+	Iterator
+	// Key returns the key of the current pair.
+	Key() interface{}
+}
+
+// SortedKVIterable enables to traverse a Container lazily in ascending key
+// order.
+type SortedKVIterable interface {
+	// SortedKVIterator returns a KVIterator over the (key,value) pairs of
+	// the Container in ascending key order. Modifying the Container
+	// invalidates the KVIterator.
+	SortedKVIterator() KVIterator
+}
+
 // Peeker gives access to the top without modifying the Container.
 type Peeker interface {
 	// Peek inspects topmost element of Container without modifying the Container.
@@ -137,6 +163,13 @@ type Set interface {
 	Has(interface{}) bool
 	// Delete removes the elements from Set, if they are present.
 	Delete(...interface{})
+	// RemoveAll removes the elements from Set, like Delete, and returns the
+	// number of elements which were present.
+	RemoveAll(values ...interface{}) int
+	// Each calls fn with every element of the Set, in no particular order.
+	Each(fn func(value interface{}))
+	// Count returns the number of elements of the Set satisfying predicate.
+	Count(predicate func(value interface{}) bool) int
 }
 
 // Map is an abstract data structure composed of a Container of
@@ -154,6 +187,40 @@ type Map interface {
 	// Delete removes a (key,value) pair from the Map, unmapping
 	// a given key from its value.
 	Delete(interface{})
+	// DeleteAll removes the (key,value) pairs of all the keys from the Map,
+	// and returns the number of keys which were present.
+	DeleteAll(keys ...interface{}) int
+	// Clone returns an independent copy of the Map, of the same type and
+	// with the same configuration and iteration order. Keys and values are
+	// not copied.
+	Clone() Map
+	// ComputeIfAbsent returns the value bound to key. If key is not in the
+	// Map, supplier is called once and its result is bound to key and
+	// returned.
+	ComputeIfAbsent(key interface{}, supplier func() interface{}) interface{}
+	// ComputeIfPresent rebinds key to the result of remap called with its
+	// current value. It does nothing if key is not in the Map.
+	ComputeIfPresent(key interface{}, remap func(old interface{}) interface{})
+	// Invert returns a new Map mapping each value to its key. If several
+	// keys share the same value, the last one visited wins. Panics if a
+	// value is not comparable, see MustBeComparable.
+	Invert() Map
+	// FilterKeys returns a new Map with the (key,value) pairs whose key
+	// satisfies predicate. The Map is not modified.
+	FilterKeys(predicate func(key interface{}) bool) Map
+	// FilterValues returns a new Map with the (key,value) pairs whose value
+	// satisfies predicate. The Map is not modified.
+	FilterValues(predicate func(value interface{}) bool) Map
+	// FilterEntries returns a new Map with the (key,value) pairs satisfying
+	// predicate. The Map is not modified.
+	FilterEntries(predicate func(key, value interface{}) bool) Map
+	// MapValues returns a new Map binding each key to its value projected
+	// by transform. The Map is not modified.
+	MapValues(transform func(value interface{}) interface{}) Map
+	// MapEntries returns a new Map with each (key,value) pair projected by
+	// transform. If several pairs are projected to the same key, the last
+	// one visited wins. The Map is not modified.
+	MapEntries(transform func(key, value interface{}) (interface{}, interface{})) Map
 }
 
 // Tree is an abstract data structure that simulates a hierarchical
@@ -166,6 +233,19 @@ type Tree interface {
 	// Height is the length of the longest downward path to a leaf
 	// from the root.
 	Height() int
+	// MinHeight is the length of the shortest downward path to a leaf
+	// from the root.
+	MinHeight() int
+	// Diameter is the length of the longest path between any two nodes
+	// of the Tree, which does not necessarily pass through the root.
+	Diameter() int
+	// IsBalanced indicates if the heights of the subtrees of every node
+	// of the Tree differ by at most one.
+	IsBalanced() bool
+	// Search returns the keys from the root down to the node matching key,
+	// both included. Returns an empty Slice and false if there is no such
+	// node.
+	Search(key interface{}) (path Slice, found bool)
 }
 
 // Slice is a slice wrapper which provides various handy methods.
@@ -186,32 +266,164 @@ type Slice interface {
 	Prepend(...interface{}) Slice
 	// Concat combines two Slices.
 	Concat(slice Slice) Slice
-	// Reverse reverses the elements in a Slice in place.
+	// Intersperse returns a new Slice with sep inserted between each pair
+	// of adjacent elements.
+	Intersperse(sep interface{}) Slice
+	// Join converts all the elements of a Slice into strings with format
+	// and concatenates them, separated by sep.
+	Join(sep string, format func(interface{}) string) string
+	// Union returns a new Slice with the distinct elements of the Slice
+	// then of other, in order of first occurrence. Elements are compared
+	// with ==, so they must be comparable, see MustBeComparable, and
+	// pointers are equal only if they point to the same value.
+	Union(other Slice) Slice
+	// Intersect returns a new Slice with the distinct elements of the Slice
+	// which are also in other, in order of first occurrence. Elements are
+	// compared with ==, like in Union.
+	Intersect(other Slice) Slice
+	// SubtractSlice returns a new Slice with the elements of the Slice which
+	// are not in other, keeping their duplicates and order. Elements are
+	// compared with ==, like in Union.
+	SubtractSlice(other Slice) Slice
+	// Reverse reverses the elements in a Slice in place, and returns the
+	// Slice itself.
 	Reverse() Slice
+	// Reversed returns a new Slice with the elements in reverse order,
+	// leaving the Slice unchanged, unlike Reverse.
+	Reversed() Slice
+	// ReverseRange reverses the elements in the [i, j) range of a Slice in
+	// place. Panics if the range is out of bounds.
+	ReverseRange(i, j int) Slice
+	// Rotate cyclically shifts the elements of a Slice in place by k
+	// positions to the right, or to the left if k is negative. k is
+	// normalized modulo the size of the Slice.
+	Rotate(k int) Slice
+	// CopyWithin shallow copies the elements in the [start, end) range of
+	// a Slice to the position starting at target, in place, without
+	// changing the size of the Slice. Overlapping ranges are copied as if
+	// through a temporary copy. Negative indexes count back from the end.
+	CopyWithin(target, start, end int) Slice
+	// Fill sets the elements in the [start, end) range of a Slice to value,
+	// in place. Negative indexes count back from the end, and out of range
+	// indexes are clamped to the bounds of the Slice.
+	Fill(value interface{}, start, end int) Slice
 	// Sort sorts a Slice in place.
 	Sort(compare func(raw []interface{}, i, j int) bool) Slice
+	// InsertSorted inserts value in place into a Slice sorted in ascending
+	// order by cmp, after the elements comparing equal to it, so that the
+	// Slice stays sorted, and returns the Slice.
+	InsertSorted(value interface{}, cmp func(a, b interface{}) int) Slice
+	// SearchSorted binary searches value in a Slice sorted in ascending
+	// order by cmp. It returns the index of an element comparing equal to
+	// value and true, or the index where value would be inserted and false.
+	SearchSorted(value interface{}, cmp func(a, b interface{}) int) (index int, found bool)
 	// Slice returns a copy of a section of a Slice.
 	Slice(...int) Slice
+	// Window returns a Slice of overlapping sub-Slices of the given size,
+	// sliding by one element, e.g. [1,2,3] with size 2 yields [[1,2],[2,3]].
+	// Returns an empty Slice if size is larger than the Slice, and panics
+	// if size is not positive.
+	Window(size int) Slice
+	// Cycle returns an Iterator yielding the elements of the Slice in
+	// order, wrapping to the first one after the last one forever. It
+	// yields nothing if the Slice is empty.
+	Cycle() Iterator
+	// SplitAt returns two new Slices holding the elements before index and
+	// the ones from index on. index is clamped to the [0, Size] range.
+	SplitAt(index int) (left Slice, right Slice)
+	// SplitBy returns a Slice of Slices holding the runs of elements between
+	// the elements satisfying isDelimiter, which are dropped. Consecutive,
+	// leading and trailing delimiters yield empty Slices, e.g. [0,1,0,0]
+	// split by zeros yields [[],[1],[],[]].
+	SplitBy(isDelimiter func(interface{}) bool) Slice
 	// Splice removes elements from a Slice and, if necessary, inserts
 	// new elements in their place, returning the deleted elements.
 	// Remove all elements after the start position(including start one)
-	// if deleteCount is -1.
+	// if deleteCount is -1, or if it is larger than the number of elements
+	// after start. A negative start is an offset from the end, clamped to
+	// 0, and a start larger than the size is clamped to the size, so that
+	// elements are appended.
 	Splice(start int, deleteCount int, elements ...interface{}) Slice
 	// Map projects every element in Slice with the projection function
 	// and returns a Slice that contains all the results.
 	Map(project func(interface{}) interface{}) Slice
+	// MapIndexed is like Map, but also passes the index of each element to
+	// the projection function.
+	MapIndexed(project func(index int, value interface{}) interface{}) Slice
+	// Tap calls fn with the Slice itself and returns the Slice unchanged,
+	// to run side effects such as logging in the middle of a chain.
+	Tap(fn func(Slice)) Slice
+	// TapEach calls fn with every element of a Slice and returns the Slice
+	// unchanged.
+	TapEach(fn func(interface{})) Slice
+	// ToMap returns a Map binding the key derived from each element by
+	// keyFn to the element. If several elements derive the same key, the
+	// last one wins.
+	ToMap(keyFn func(interface{}) interface{}) Map
+	// ToMapBy is like ToMap, but binds each key to the value projected from
+	// the element by valueFn.
+	ToMapBy(keyFn, valueFn func(interface{}) interface{}) Map
 	// Filter returns the elements of a Slice that meet the condition
 	// specified in a predicate function.
 	Filter(predicate func(interface{}) bool) Slice
+	// FilterIndexed is like Filter, but also passes the index of each
+	// element to the predicate function.
+	FilterIndexed(predicate func(index int, value interface{}) bool) Slice
 	// Reject returns the elements of a Slice that does not meet the
 	// condition specified in a predicate function.
 	Reject(predicate func(interface{}) bool) Slice
+	// FilterMap projects every element in Slice with fn in a single pass,
+	// and returns a Slice of the projected values for which fn returns
+	// true.
+	FilterMap(fn func(interface{}) (interface{}, bool)) Slice
+	// Compact returns a new Slice with all nil elements removed, preserving
+	// the order of the remaining elements.
+	Compact() Slice
+	// CompactBy returns a new Slice with all elements for which isEmpty
+	// returns true removed, preserving the order of the remaining elements.
+	CompactBy(isEmpty func(interface{}) bool) Slice
+	// Dedup returns a new Slice where each run of consecutive elements
+	// equal with == is collapsed into its first element, like Unix uniq,
+	// e.g. [1,1,2,1] yields [1,2,1].
+	Dedup() Slice
+	// DedupBy is like Dedup, but compares consecutive elements with eq.
+	DedupBy(eq func(a, b interface{}) bool) Slice
+	// OfType returns a new Slice with the elements whose dynamic type is
+	// the same as the one of example.
+	OfType(example interface{}) Slice
+	// OfTypeName returns a new Slice with the elements whose dynamic type
+	// is named name, as formatted by the %T verb, e.g. "int" or
+	// "*gods.PrefixMap".
+	OfTypeName(name string) Slice
 	// Every determines whether all the elements of a Slice satisfy the
 	// specified predicate function.
 	Every(predicate func(interface{}) bool) bool
 	// Some determines whether the specified predicate function returns
 	// true for any element of a Slice.
 	Some(predicate func(interface{}) bool) bool
+	// EqualSlice determines whether a Slice and other have the same size
+	// and all their corresponding elements are equal according to eq.
+	EqualSlice(other Slice, eq func(a, b interface{}) bool) bool
+	// DeepEqualSlice is like EqualSlice, comparing elements with
+	// reflect.DeepEqual.
+	DeepEqualSlice(other Slice) bool
+	// IndexOfBy returns the index of the first element of a Slice equal to
+	// value according to eq, or -1 if there is none.
+	IndexOfBy(value interface{}, eq func(a, b interface{}) bool) int
+	// IncludesBy determines whether a Slice has an element equal to value
+	// according to eq.
+	IncludesBy(value interface{}, eq func(a, b interface{}) bool) bool
+	// MinBy returns the element of a Slice with the lowest key, the first
+	// one in case of ties. Returns (nil, false) if the Slice is empty.
+	MinBy(key func(interface{}) float64) (interface{}, bool)
+	// MaxBy returns the element of a Slice with the highest key, the first
+	// one in case of ties. Returns (nil, false) if the Slice is empty.
+	MaxBy(key func(interface{}) float64) (interface{}, bool)
+	// SumBy returns the sum of the keys of all the elements of a Slice.
+	SumBy(key func(interface{}) float64) float64
+	// AverageBy returns the average of the keys of all the elements of a
+	// Slice. Returns (0, false) if the Slice is empty.
+	AverageBy(key func(interface{}) float64) (float64, bool)
 	// Reduce calls the specified callback function for all the elements in a Slice.
 	// The return value of the callback function is the accumulated result, and is
 	// provided as an argument in the next call to the callback function.
@@ -221,6 +433,23 @@ type Slice interface {
 	// accumulated result, and is provided as an argument in the next call to the
 	// callback function.
 	ReduceRight(fn func(previousValue, currentValue interface{}, currentIndex int) interface{}, initialValue interface{}) interface{}
+	// ReduceWhile is like Reduce, but stops as soon as fn returns false,
+	// returning the accumulated result so far, including the one returned
+	// by that last call.
+	ReduceWhile(fn func(acc, cur interface{}, index int) (interface{}, bool), initial interface{}) interface{}
+	// ReduceLeaves is like Reduce, but descends recursively into the
+	// elements which are Slices or []interface{}, folding only the other
+	// elements, the leaves, from left to right. On a Slice without nested
+	// ones, it folds the same elements as Reduce.
+	ReduceLeaves(fn func(acc, leaf interface{}) interface{}, initial interface{}) interface{}
+	// Scan is like Reduce, but returns a Slice of all the intermediate
+	// accumulated results instead of only the last one, e.g. the prefix
+	// sums of a Slice. Returns an empty Slice if the Slice is empty.
+	Scan(fn func(acc, cur interface{}, index int) interface{}, initial interface{}) Slice
+	// ScanRight is like ReduceRight, but returns a Slice of all the
+	// intermediate accumulated results, in the order they are computed.
+	// Returns an empty Slice if the Slice is empty.
+	ScanRight(fn func(acc, cur interface{}, index int) interface{}, initial interface{}) Slice
 }
 
 // Slicer can convert all elements in a Container to a Slice.
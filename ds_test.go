@@ -3,3 +3,144 @@ package gods
 import "testing"
 
 func TestGods(t *testing.T) {}
+
+func equalRaw(a, b []interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// unimplementedMap lets testMap embed the Map interface.
+type unimplementedMap = Map
+
+// testMap is a minimal Map backed by a Go map, used to test the package
+// functions operating on Maps. Methods it does not implement panic through
+// the nil embedded interface.
+type testMap struct {
+	unimplementedMap
+	m map[interface{}]interface{}
+}
+
+func newTestMap(kvs ...interface{}) *testMap {
+	m := &testMap{m: make(map[interface{}]interface{})}
+	for i := 0; i < len(kvs); i += 2 {
+		m.m[kvs[i]] = kvs[i+1]
+	}
+	return m
+}
+
+func (m *testMap) Empty() bool { return len(m.m) == 0 }
+func (m *testMap) Size() int   { return len(m.m) }
+func (m *testMap) Clear()      { m.m = make(map[interface{}]interface{}) }
+
+func (m *testMap) Get(key interface{}) (interface{}, bool) {
+	v, ok := m.m[key]
+	return v, ok
+}
+
+func (m *testMap) Has(key interface{}) bool {
+	_, ok := m.m[key]
+	return ok
+}
+
+func (m *testMap) Add(key, value interface{}) Map {
+	m.m[key] = value
+	return m
+}
+
+func (m *testMap) Delete(key interface{}) { delete(m.m, key) }
+
+// testSet is a minimal Set backed by a Go map, used to test the package
+// functions operating on Sets.
+type testSet struct {
+	m map[interface{}]struct{}
+}
+
+func newTestSet(values ...interface{}) *testSet {
+	s := &testSet{m: make(map[interface{}]struct{})}
+	s.Add(values...)
+	return s
+}
+
+func (s *testSet) Empty() bool { return len(s.m) == 0 }
+func (s *testSet) Size() int   { return len(s.m) }
+func (s *testSet) Clear()      { s.m = make(map[interface{}]struct{}) }
+
+func (s *testSet) Add(values ...interface{}) Set {
+	for _, v := range values {
+		s.m[v] = struct{}{}
+	}
+	return s
+}
+
+func (s *testSet) Has(v interface{}) bool {
+	_, ok := s.m[v]
+	return ok
+}
+
+func (s *testSet) Delete(values ...interface{}) {
+	for _, v := range values {
+		delete(s.m, v)
+	}
+}
+
+func (s *testSet) RemoveAll(values ...interface{}) int {
+	removed := 0
+	for _, v := range values {
+		if s.Has(v) {
+			delete(s.m, v)
+			removed++
+		}
+	}
+	return removed
+}
+
+func (s *testSet) Each(fn func(value interface{})) {
+	for v := range s.m {
+		fn(v)
+	}
+}
+
+func (s *testSet) Count(predicate func(value interface{}) bool) int {
+	n := 0
+	for v := range s.m {
+		if predicate(v) {
+			n++
+		}
+	}
+	return n
+}
+
+func (s *testSet) RangeWithKey(fn KeyRangerFunc) {
+	for v := range s.m {
+		if !fn(v) {
+			return
+		}
+	}
+}
+
+// eachCollector is implemented by the Containers with an Each method.
+type eachCollector interface {
+	Each(IndexRangerFunc)
+}
+
+// collectEach returns the values visited by Each until limit values were
+// visited, checking that indexes are consecutive.
+func collectEach(t *testing.T, c eachCollector, limit int) []interface{} {
+	t.Helper()
+	var values []interface{}
+	c.Each(func(index int, value interface{}) bool {
+		if index != len(values) {
+			t.Errorf("expected index %d, got %d", len(values), index)
+		}
+		values = append(values, value)
+		return len(values) < limit
+	})
+	return values
+}
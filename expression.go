@@ -0,0 +1,141 @@
+package gods
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var (
+	// ErrDivisionByZero is returned when evaluating a division by zero.
+	ErrDivisionByZero = errors.New("gods: division by zero")
+	// ErrMalformedExpression is returned when evaluating an expression with
+	// unknown tokens or mismatched operators and operands.
+	ErrMalformedExpression = errors.New("gods: malformed expression")
+)
+
+// operatorPrecedence maps the supported binary operators, all left
+// associative, to their precedence.
+var operatorPrecedence = map[string]int{
+	"+": 1,
+	"-": 1,
+	"*": 2,
+	"/": 2,
+}
+
+// EvalRPN evaluates an arithmetic expression in reverse Polish notation,
+// e.g. [3 4 2 * +] for 3 + 4 * 2. Operands are either numbers or strings
+// parsed as float64, and operators are the strings "+", "-", "*" and "/".
+// Returns an error wrapping ErrMalformedExpression or ErrDivisionByZero if
+// the expression can not be evaluated.
+func EvalRPN(tokens Slice) (float64, error) {
+	var operands []float64
+	var err error
+	tokens.RangeWithIndex(func(index int, token interface{}) bool {
+		if op, ok := token.(string); ok {
+			if _, ok := operatorPrecedence[op]; ok {
+				if len(operands) < 2 {
+					err = fmt.Errorf("%w: missing operand for %q at %d", ErrMalformedExpression, op, index)
+					return false
+				}
+				a, b := operands[len(operands)-2], operands[len(operands)-1]
+				operands = operands[:len(operands)-2]
+				var result float64
+				if result, err = applyOperator(op, a, b); err != nil {
+					return false
+				}
+				operands = append(operands, result)
+				return true
+			}
+		}
+		var operand float64
+		if operand, err = parseOperand(token); err != nil {
+			err = fmt.Errorf("%w: %v at %d", ErrMalformedExpression, err, index)
+			return false
+		}
+		operands = append(operands, operand)
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(operands) != 1 {
+		return 0, fmt.Errorf("%w: %d operands left", ErrMalformedExpression, len(operands))
+	}
+	return operands[0], nil
+}
+
+// InfixToRPN converts the tokens of an infix arithmetic expression, e.g.
+// [3 + 4 * ( 2 - 1 )], to reverse Polish notation with the shunting-yard
+// algorithm. Operators are the strings "+", "-", "*" and "/", grouped with
+// the strings "(" and ")", and any other token is an operand. Unmatched
+// parentheses are kept in the result, so that EvalRPN rejects it.
+func InfixToRPN(tokens Slice) Slice {
+	output := tokens.Slice(0, 0)
+	var operators []string
+	tokens.RangeWithIndex(func(_ int, token interface{}) bool {
+		s, _ := token.(string)
+		switch precedence, isOperator := operatorPrecedence[s]; {
+		case isOperator:
+			for len(operators) > 0 {
+				top := operators[len(operators)-1]
+				if top == "(" || operatorPrecedence[top] < precedence {
+					break
+				}
+				output.Append(top)
+				operators = operators[:len(operators)-1]
+			}
+			operators = append(operators, s)
+		case s == "(":
+			operators = append(operators, s)
+		case s == ")":
+			for len(operators) > 0 && operators[len(operators)-1] != "(" {
+				output.Append(operators[len(operators)-1])
+				operators = operators[:len(operators)-1]
+			}
+			if len(operators) == 0 {
+				output.Append(s)
+			} else {
+				operators = operators[:len(operators)-1]
+			}
+		default:
+			output.Append(token)
+		}
+		return true
+	})
+	for i := len(operators) - 1; i >= 0; i-- {
+		output.Append(operators[i])
+	}
+	return output
+}
+
+func applyOperator(op string, a, b float64) (float64, error) {
+	switch op {
+	case "+":
+		return a + b, nil
+	case "-":
+		return a - b, nil
+	case "*":
+		return a * b, nil
+	}
+	if b == 0 {
+		return 0, ErrDivisionByZero
+	}
+	return a / b, nil
+}
+
+func parseOperand(token interface{}) (float64, error) {
+	switch v := token.(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid operand %q", v)
+		}
+		return f, nil
+	}
+	return 0, fmt.Errorf("invalid operand of type %T", token)
+}
@@ -0,0 +1,87 @@
+package gods
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func tokenize(expression string) *ArraySlice {
+	s := NewArraySlice()
+	for _, token := range strings.Fields(expression) {
+		s.Append(token)
+	}
+	return s
+}
+
+func TestEvalRPN(t *testing.T) {
+	tests := []struct {
+		tokens *ArraySlice
+		want   float64
+	}{
+		{tokenize("3"), 3},
+		{tokenize("3 4 +"), 7},
+		{tokenize("3 4 2 * +"), 11},
+		{tokenize("5 1 2 + 4 * + 3 -"), 14},
+		{tokenize("1.5 2 /"), 0.75},
+		{NewArraySlice(2, 2.5, "*"), 5},
+	}
+	for _, tt := range tests {
+		got, err := EvalRPN(tt.tokens)
+		if err != nil || got != tt.want {
+			t.Errorf("EvalRPN(%v) = %v, %v, expected %v", tt.tokens.Raw(), got, err, tt.want)
+		}
+	}
+}
+
+func TestEvalRPNErrors(t *testing.T) {
+	tests := []struct {
+		tokens *ArraySlice
+		err    error
+	}{
+		{tokenize("1 0 /"), ErrDivisionByZero},
+		{tokenize("1 2 2 - /"), ErrDivisionByZero},
+		{tokenize(""), ErrMalformedExpression},
+		{tokenize("1 +"), ErrMalformedExpression},
+		{tokenize("+"), ErrMalformedExpression},
+		{tokenize("1 2"), ErrMalformedExpression},
+		{tokenize("1 x +"), ErrMalformedExpression},
+		{NewArraySlice(1, true, "+"), ErrMalformedExpression},
+	}
+	for _, tt := range tests {
+		if _, err := EvalRPN(tt.tokens); !errors.Is(err, tt.err) {
+			t.Errorf("EvalRPN(%v) error = %v, expected %v", tt.tokens.Raw(), err, tt.err)
+		}
+	}
+}
+
+func TestInfixToRPN(t *testing.T) {
+	tests := []struct {
+		infix, rpn string
+		value      float64
+	}{
+		{"3 + 4", "[3 4 +]", 7},
+		{"3 + 4 * 2", "[3 4 2 * +]", 11},
+		{"3 - 4 - 2", "[3 4 - 2 -]", -3},
+		{"( 3 + 4 ) * 2", "[3 4 + 2 *]", 14},
+		{"3 + 4 * 2 / ( 1 - 5 )", "[3 4 2 * 1 5 - / +]", 1},
+		{"( ( 1 ) )", "[1]", 1},
+	}
+	for _, tt := range tests {
+		rpn := InfixToRPN(tokenize(tt.infix))
+		if got := fmt.Sprint(rpn.Raw()); got != tt.rpn {
+			t.Errorf("InfixToRPN(%q) = %s, expected %s", tt.infix, got, tt.rpn)
+			continue
+		}
+		if got, err := EvalRPN(rpn); err != nil || got != tt.value {
+			t.Errorf("EvalRPN(InfixToRPN(%q)) = %v, %v, expected %v", tt.infix, got, err, tt.value)
+		}
+	}
+
+	for _, infix := range []string{"( 1 + 2", "1 + 2 )", "1 +", "1 2"} {
+		if _, err := EvalRPN(InfixToRPN(tokenize(infix))); !errors.Is(err, ErrMalformedExpression) {
+			t.Errorf("expected malformed %q to be rejected, got %v", infix, err)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package gods
+
+// FibonacciHeap is a PriorityQueue backed by a Fibonacci heap, which pops
+// the least element according to its comparison function first. Push,
+// Meld and DecreaseKey, through the handle returned when pushing an
+// element, are O(1) amortized, and Pop is O(log n) amortized.
+type FibonacciHeap struct {
+	cmp   func(a, b interface{}) int
+	min   *FibonacciHeapNode // in the circular root list
+	size  int
+	owner *fibOwner
+}
+
+// fibOwner identifies the FibonacciHeap holding a node. Melding a heap
+// points its owner to the one of the receiving heap, so that the handles
+// of the melded heap remain valid in O(1).
+type fibOwner struct {
+	parent *fibOwner
+}
+
+func (o *fibOwner) find() *fibOwner {
+	for o.parent != nil {
+		if o.parent.parent != nil {
+			o.parent = o.parent.parent
+		}
+		o = o.parent
+	}
+	return o
+}
+
+// FibonacciHeapNode is a handle on an element of a FibonacciHeap.
+type FibonacciHeapNode struct {
+	value       interface{}
+	owner       *fibOwner
+	parent      *FibonacciHeapNode
+	child       *FibonacciHeapNode // any node of the circular child list
+	left, right *FibonacciHeapNode // siblings in a circular list
+	degree      int
+	mark        bool // lost a child since it became a child itself
+}
+
+// Value returns the element held by the node.
+func (n *FibonacciHeapNode) Value() interface{} {
+	return n.value
+}
+
+var _ PriorityQueue = (*FibonacciHeap)(nil)
+
+// NewFibonacciHeap creates an empty FibonacciHeap ordered by cmp, which
+// returns a negative number if a has a higher priority than b, zero if they
+// have the same priority, and a positive number otherwise. Pass a reversed
+// comparison function to pop the greatest element first.
+func NewFibonacciHeap(cmp func(a, b interface{}) int) *FibonacciHeap {
+	return &FibonacciHeap{cmp: cmp, owner: &fibOwner{}}
+}
+
+// Empty indicates if the FibonacciHeap is empty.
+func (h *FibonacciHeap) Empty() bool {
+	return h.size == 0
+}
+
+// Size retrieves FibonacciHeap size.
+func (h *FibonacciHeap) Size() int {
+	return h.size
+}
+
+// Clear resets FibonacciHeap, it will be empty with size 0. All the handles
+// on its former elements become invalid.
+func (h *FibonacciHeap) Clear() {
+	h.min = nil
+	h.size = 0
+	h.owner = &fibOwner{}
+}
+
+// Peek inspects the highest priority element of FibonacciHeap without
+// removing it. Returns (nil, false) if the FibonacciHeap is empty.
+func (h *FibonacciHeap) Peek() (interface{}, bool) {
+	if h.min == nil {
+		return nil, false
+	}
+	return h.min.value, true
+}
+
+// Push adds an element to the FibonacciHeap.
+func (h *FibonacciHeap) Push(v interface{}) {
+	h.Insert(v)
+}
+
+// Insert adds an element to the FibonacciHeap and returns a handle on it,
+// which can be passed to DecreaseKey.
+func (h *FibonacciHeap) Insert(v interface{}) *FibonacciHeapNode {
+	n := &FibonacciHeapNode{value: v, owner: h.owner}
+	n.left, n.right = n, n
+	h.addRoot(n)
+	h.size++
+	return n
+}
+
+// Meld moves all the elements of other into FibonacciHeap in O(1), leaving
+// other empty. The handles on the elements of other remain valid, and must
+// then be used with FibonacciHeap. Both heaps must use the same ordering.
+func (h *FibonacciHeap) Meld(other *FibonacciHeap) {
+	if other == h || other.min == nil {
+		return
+	}
+	other.owner.parent = h.owner
+	if h.min == nil {
+		h.min = other.min
+	} else {
+		// Splice the two circular root lists.
+		a, b := h.min.right, other.min.left
+		h.min.right, other.min.left = other.min, h.min
+		a.left, b.right = b, a
+		if mustCompare(other.min.value, h.min.value, h.cmp) < 0 {
+			h.min = other.min
+		}
+	}
+	h.size += other.size
+	other.min, other.size = nil, 0
+	other.owner = &fibOwner{}
+}
+
+// Pop ejects the highest priority element of FibonacciHeap, and removes
+// it. Returns nil if the FibonacciHeap is empty.
+func (h *FibonacciHeap) Pop() interface{} {
+	z := h.min
+	if z == nil {
+		return nil
+	}
+	// Move the children of z to the root list.
+	for z.child != nil {
+		c := z.child
+		z.child = c.right
+		if z.child == c {
+			z.child = nil
+		}
+		unlinkFib(c)
+		c.parent, c.mark = nil, false
+		spliceFib(z, c)
+	}
+	if z.right == z {
+		h.min = nil
+	} else {
+		h.min = z.right
+		unlinkFib(z)
+		h.consolidate()
+	}
+	h.size--
+	*z = FibonacciHeapNode{value: z.value}
+	return z.value
+}
+
+// DecreaseKey replaces the element of node with v, which must have a
+// priority at least as high as the one it replaces. Panics if node is not
+// in the FibonacciHeap or v has a lower priority.
+func (h *FibonacciHeap) DecreaseKey(node *FibonacciHeapNode, v interface{}) {
+	if node.owner == nil || node.owner.find() != h.owner {
+		panic("gods: node is not in the FibonacciHeap")
+	}
+	if mustCompare(v, node.value, h.cmp) > 0 {
+		panic("gods: DecreaseKey can not lower the priority of an element")
+	}
+	node.value = v
+	if parent := node.parent; parent != nil && mustCompare(v, parent.value, h.cmp) < 0 {
+		h.cut(node)
+		// Cascading cut: cut the ancestors until one had not lost a child.
+		for n := parent; n.parent != nil; {
+			if !n.mark {
+				n.mark = true
+				break
+			}
+			next := n.parent
+			h.cut(n)
+			n = next
+		}
+	}
+	if mustCompare(v, h.min.value, h.cmp) < 0 {
+		h.min = node
+	}
+}
+
+// addRoot adds the single node n to the root list.
+func (h *FibonacciHeap) addRoot(n *FibonacciHeapNode) {
+	if h.min == nil {
+		h.min = n
+		return
+	}
+	spliceFib(h.min, n)
+	if mustCompare(n.value, h.min.value, h.cmp) < 0 {
+		h.min = n
+	}
+}
+
+// cut moves n from the child list of its parent to the root list.
+func (h *FibonacciHeap) cut(n *FibonacciHeapNode) {
+	parent := n.parent
+	if parent.child == n {
+		parent.child = n.right
+		if parent.child == n {
+			parent.child = nil
+		}
+	}
+	parent.degree--
+	unlinkFib(n)
+	n.parent, n.mark = nil, false
+	spliceFib(h.min, n)
+}
+
+// consolidate links the roots of equal degree until all the roots have
+// distinct degrees, and finds the new minimum.
+func (h *FibonacciHeap) consolidate() {
+	var roots []*FibonacciHeapNode
+	for n := h.min; ; {
+		roots = append(roots, n)
+		if n = n.right; n == h.min {
+			break
+		}
+	}
+
+	var byDegree []*FibonacciHeapNode
+	for _, x := range roots {
+		unlinkFib(x)
+		for {
+			for len(byDegree) <= x.degree {
+				byDegree = append(byDegree, nil)
+			}
+			y := byDegree[x.degree]
+			if y == nil {
+				break
+			}
+			byDegree[x.degree] = nil
+			if mustCompare(y.value, x.value, h.cmp) < 0 {
+				x, y = y, x
+			}
+			// Make y a child of x.
+			y.parent, y.mark = x, false
+			if x.child == nil {
+				x.child = y
+			} else {
+				spliceFib(x.child, y)
+			}
+			x.degree++
+		}
+		byDegree[x.degree] = x
+	}
+
+	h.min = nil
+	for _, n := range byDegree {
+		if n != nil {
+			h.addRoot(n)
+		}
+	}
+}
+
+// spliceFib inserts the single node n to the right of a in its list.
+func spliceFib(a, n *FibonacciHeapNode) {
+	n.left, n.right = a, a.right
+	a.right.left = n
+	a.right = n
+}
+
+// unlinkFib removes n from its list, making it a list of its own.
+func unlinkFib(n *FibonacciHeapNode) {
+	n.left.right = n.right
+	n.right.left = n.left
+	n.left, n.right = n, n
+}
@@ -0,0 +1,235 @@
+package gods
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestFibonacciHeap(t *testing.T) {
+	h := NewFibonacciHeap(intCompare)
+	if !h.Empty() || h.Pop() != nil {
+		t.Fatal("expected new heap to be empty")
+	}
+	if _, ok := h.Peek(); ok {
+		t.Error("expected Peek on empty heap to fail")
+	}
+
+	for _, v := range []int{5, 3, 8, 1, 9, 1} {
+		h.Push(v)
+	}
+	for _, want := range []int{1, 1, 3, 5, 8, 9} {
+		if v, _ := h.Peek(); v != want {
+			t.Errorf("expected Peek to return %d, got %v", want, v)
+		}
+		if v := h.Pop(); v != want {
+			t.Errorf("expected %d, got %v", want, v)
+		}
+	}
+	if !h.Empty() {
+		t.Error("expected heap to be empty after popping all")
+	}
+}
+
+// TestFibonacciHeapRandomized checks the extraction order against a sorted
+// reference across randomized pushes, pops, decrease-keys and melds.
+func TestFibonacciHeapRandomized(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	h := NewFibonacciHeap(intCompare)
+	var live []*FibonacciHeapNode
+
+	removeLive := func(v interface{}) {
+		for i, n := range live {
+			if n.owner == nil && n.value == v {
+				live = append(live[:i], live[i+1:]...)
+				return
+			}
+		}
+		t.Fatalf("popped %v is not a live node", v)
+	}
+
+	for i := 0; i < 5000; i++ {
+		switch op := r.Intn(10); {
+		case op < 4:
+			live = append(live, h.Insert(r.Intn(100000)))
+		case op < 6 && len(live) > 0:
+			n := live[r.Intn(len(live))]
+			h.DecreaseKey(n, n.Value().(int)-r.Intn(1000))
+		case op < 9 && len(live) > 0:
+			min := live[0].Value().(int)
+			for _, n := range live {
+				if v := n.Value().(int); v < min {
+					min = v
+				}
+			}
+			v := h.Pop()
+			if v != min {
+				t.Fatalf("expected min %d at step %d, got %v", min, i, v)
+			}
+			removeLive(v)
+		case op == 9:
+			other := NewFibonacciHeap(intCompare)
+			for j := 0; j < r.Intn(20); j++ {
+				live = append(live, other.Insert(r.Intn(100000)))
+			}
+			h.Meld(other)
+			if !other.Empty() {
+				t.Fatal("expected melded heap to be empty")
+			}
+		}
+		if h.Size() != len(live) {
+			t.Fatalf("expected size %d at step %d, got %d", len(live), i, h.Size())
+		}
+	}
+
+	var reference []int
+	for _, n := range live {
+		reference = append(reference, n.Value().(int))
+	}
+	sort.Ints(reference)
+	for i, want := range reference {
+		if v := h.Pop(); v != want {
+			t.Fatalf("expected %d at %d, got %v", want, i, v)
+		}
+	}
+}
+
+// fibChild returns the child of n with the given degree, or nil.
+func fibChild(n *FibonacciHeapNode, degree int) *FibonacciHeapNode {
+	for c := n.child; c != nil; {
+		if c.degree == degree {
+			return c
+		}
+		if c = c.right; c == n.child {
+			break
+		}
+	}
+	return nil
+}
+
+func TestFibonacciHeapCascadingCut(t *testing.T) {
+	h := NewFibonacciHeap(intCompare)
+	for i := 0; i <= 16; i++ {
+		h.Insert(i)
+	}
+	// Popping 0 consolidates the 16 other roots into a single tree of
+	// degree 4, along the path root, c1, c2, c3 of degrees 4, 3, 2, 1.
+	h.Pop()
+	root := h.min
+	c1 := fibChild(root, 3)
+	c2 := fibChild(c1, 2)
+	c3 := fibChild(c2, 1)
+	if root.degree != 4 || c3 == nil {
+		t.Fatalf("expected a binomial tree of degree 4, got degree %d", root.degree)
+	}
+
+	// Cutting a leaf under c1 and c2 marks them.
+	h.DecreaseKey(fibChild(c1, 0), -1)
+	h.DecreaseKey(fibChild(c2, 0), -2)
+	if !c1.mark || !c2.mark {
+		t.Fatal("expected the parents of the cut leaves to be marked")
+	}
+	// Cutting c3 then cascades through c2 and c1 up to the root.
+	h.DecreaseKey(c3, -3)
+	for i, n := range []*FibonacciHeapNode{c1, c2, c3} {
+		if n.parent != nil || n.mark {
+			t.Errorf("expected node %d of the path to be an unmarked root", i+1)
+		}
+	}
+	if root.mark || root.degree != 3 {
+		t.Errorf("expected the root to lose c1 and stay unmarked, got degree %d", root.degree)
+	}
+
+	for _, want := range []int{-3, -2, -1, 1, 2} {
+		if v := h.Pop(); v != want {
+			t.Fatalf("expected %d, got %v", want, v)
+		}
+	}
+}
+
+// TestFibonacciHeapDeepCascades decreases keys far below the others in a
+// large consolidated heap, so that cuts cascade over several levels.
+func TestFibonacciHeapDeepCascades(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	h := NewFibonacciHeap(intCompare)
+	nodes := make([]*FibonacciHeapNode, 1<<10)
+	for i := range nodes {
+		nodes[i] = h.Insert(i)
+	}
+	h.Pop()
+	nodes = nodes[1:]
+	for i := 0; i < 5000; i++ {
+		n := nodes[r.Intn(len(nodes))]
+		h.DecreaseKey(n, n.Value().(int)-r.Intn(1<<20))
+		if i%500 == 0 {
+			// Popping consolidates the cut roots into deep trees again.
+			min := h.Pop()
+			for j, n := range nodes {
+				if n.value == min && n.owner == nil {
+					nodes = append(nodes[:j], nodes[j+1:]...)
+					break
+				}
+			}
+		}
+	}
+
+	values := make([]int, len(nodes))
+	for i, n := range nodes {
+		values[i] = n.Value().(int)
+	}
+	sort.Ints(values)
+	for _, want := range values {
+		if v := h.Pop(); v != want {
+			t.Fatalf("expected %d, got %v", want, v)
+		}
+	}
+}
+
+func TestFibonacciHeapMeld(t *testing.T) {
+	a, b := NewFibonacciHeap(intCompare), NewFibonacciHeap(intCompare)
+	a.Push(4)
+	a.Push(2)
+	n := b.Insert(9)
+	b.Push(3)
+	b.Push(7)
+
+	a.Meld(b)
+	a.Meld(NewFibonacciHeap(intCompare))
+	a.Meld(a)
+	if a.Size() != 5 || !b.Empty() {
+		t.Fatalf("expected all elements in the receiver, got %d and %d", a.Size(), b.Size())
+	}
+
+	// The handle from the melded heap is now used with the receiver.
+	a.DecreaseKey(n, 1)
+	for _, want := range []int{1, 2, 3, 4, 7} {
+		if v := a.Pop(); v != want {
+			t.Errorf("expected %d, got %v", want, v)
+		}
+	}
+
+	b.Push(5)
+	if v := b.Pop(); v != 5 || !a.Empty() {
+		t.Error("expected melded heap to be usable on its own")
+	}
+}
+
+func TestFibonacciHeapDecreaseKeyPanics(t *testing.T) {
+	h := NewFibonacciHeap(intCompare)
+	n := h.Insert(5)
+	for name, fn := range map[string]func(){
+		"increase":   func() { h.DecreaseKey(n, 6) },
+		"other heap": func() { NewFibonacciHeap(intCompare).DecreaseKey(n, 1) },
+		"popped":     func() { m := h.Insert(0); h.Pop(); h.DecreaseKey(m, -1) },
+		"cleared":    func() { h.Clear(); h.DecreaseKey(n, 1) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for %s", name)
+				}
+			}()
+			fn()
+		}()
+	}
+}
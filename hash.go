@@ -0,0 +1,111 @@
+package gods
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"reflect"
+)
+
+// hash64 hashes data with FNV-1a, finalized with the MurmurHash3 mixer so
+// that all the bits of the result are well distributed.
+func hash64(data []byte) uint64 {
+	f := fnv.New64a()
+	_, _ = f.Write(data)
+	x := f.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+// hashKey hashes a comparable key, so that keys which are == hash the same.
+// Common key types are hashed from their bytes, others by structure.
+func hashKey(key interface{}) uint64 {
+	var buf [8]byte
+	switch k := key.(type) {
+	case string:
+		return hash64([]byte(k))
+	case int:
+		binary.LittleEndian.PutUint64(buf[:], uint64(k))
+		return hash64(buf[:])
+	case int64:
+		binary.LittleEndian.PutUint64(buf[:], uint64(k))
+		return hash64(buf[:])
+	case uint64:
+		binary.LittleEndian.PutUint64(buf[:], k)
+		return hash64(buf[:])
+	case float64:
+		binary.LittleEndian.PutUint64(buf[:], floatBits(k))
+		return hash64(buf[:])
+	}
+	return hash64(appendHashValue(nil, reflect.ValueOf(key)))
+}
+
+// floatBits returns the bits of f, with -0 normalized to 0 since -0 == 0.
+func floatBits(f float64) uint64 {
+	if f == 0 {
+		return 0
+	}
+	return math.Float64bits(f)
+}
+
+// appendHashValue appends to data the bytes of v to hash, walking structs,
+// arrays and interfaces so that values which are == append the same bytes.
+// Pointers, channels and unsafe pointers are hashed by address. Panics if v
+// holds a value which is not comparable.
+func appendHashValue(data []byte, v reflect.Value) []byte {
+	var buf [8]byte
+	appendUint := func(x uint64) []byte {
+		binary.LittleEndian.PutUint64(buf[:], x)
+		return append(data, buf[:]...)
+	}
+	switch v.Kind() {
+	case reflect.Invalid:
+		return append(data, 0)
+	case reflect.Bool:
+		if v.Bool() {
+			return append(data, 1)
+		}
+		return append(data, 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return appendUint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return appendUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return appendUint(floatBits(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		data = appendUint(floatBits(real(c)))
+		return appendUint(floatBits(imag(c)))
+	case reflect.String:
+		// The length keeps consecutive strings of a struct apart.
+		data = appendUint(uint64(v.Len()))
+		return append(data, v.String()...)
+	case reflect.Ptr, reflect.Chan, reflect.UnsafePointer:
+		return appendUint(uint64(v.Pointer()))
+	case reflect.Interface:
+		if v.IsNil() {
+			return append(data, 0)
+		}
+		v = v.Elem()
+		// Values of different dynamic types are not ==, so the type only
+		// spreads them.
+		data = append(data, v.Type().String()...)
+		return appendHashValue(data, v)
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			data = appendHashValue(data, v.Index(i))
+		}
+		return data
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			data = appendHashValue(data, v.Field(i))
+		}
+		return data
+	}
+	panic(fmt.Sprintf("gods: value of type %s is not comparable", v.Type()))
+}
@@ -0,0 +1,77 @@
+package gods
+
+import "container/heap"
+
+// Heapify rearranges the elements of s into a binary heap ordered by cmp in
+// O(n), with Floyd's method, and returns a PriorityQueue popping the least
+// element according to cmp first. cmp returns a negative number if a has a
+// higher priority than b, zero if they have the same priority, and a
+// positive number otherwise.
+//
+// The PriorityQueue is a view over s which allocates nothing: it works in
+// place on the array returned by s.Raw(), so pushing to or popping from it
+// mutates s, and mutating s directly breaks the heap order.
+func Heapify(s Slice, cmp func(a, b interface{}) int) PriorityQueue {
+	h := &sliceHeap{data: sliceHeapData{s: s, cmp: cmp}}
+	heap.Init(&h.data)
+	return h
+}
+
+type sliceHeap struct {
+	data sliceHeapData
+}
+
+// sliceHeapData implements heap.Interface over a Slice.
+type sliceHeapData struct {
+	s   Slice
+	cmp func(a, b interface{}) int
+}
+
+func (d *sliceHeapData) Len() int { return d.s.Size() }
+
+func (d *sliceHeapData) Less(i, j int) bool {
+	raw := d.s.Raw()
+	return mustCompare(raw[i], raw[j], d.cmp) < 0
+}
+
+func (d *sliceHeapData) Swap(i, j int) {
+	raw := d.s.Raw()
+	raw[i], raw[j] = raw[j], raw[i]
+}
+
+func (d *sliceHeapData) Push(x interface{}) { d.s.Append(x) }
+
+func (d *sliceHeapData) Pop() interface{} {
+	v, _ := d.s.Pop()
+	return v
+}
+
+func (h *sliceHeap) Empty() bool {
+	return h.data.s.Empty()
+}
+
+func (h *sliceHeap) Size() int {
+	return h.data.s.Size()
+}
+
+func (h *sliceHeap) Clear() {
+	h.data.s.Clear()
+}
+
+func (h *sliceHeap) Peek() (interface{}, bool) {
+	if h.data.s.Empty() {
+		return nil, false
+	}
+	return h.data.s.Raw()[0], true
+}
+
+func (h *sliceHeap) Push(v interface{}) {
+	heap.Push(&h.data, v)
+}
+
+func (h *sliceHeap) Pop() interface{} {
+	if h.data.s.Empty() {
+		return nil
+	}
+	return heap.Pop(&h.data)
+}
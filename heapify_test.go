@@ -0,0 +1,45 @@
+package gods
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestHeapify(t *testing.T) {
+	values := rand.New(rand.NewSource(1)).Perm(1000)
+	s := NewArraySlice()
+	reference := NewPairingHeap(intCompare)
+	for _, v := range values {
+		s.Append(v % 300)
+		reference.Push(v % 300)
+	}
+
+	comparisons := 0
+	h := Heapify(s, func(a, b interface{}) int {
+		comparisons++
+		return intCompare(a, b)
+	})
+	if comparisons > 2*len(values) {
+		t.Errorf("expected at most %d comparisons to heapify, got %d", 2*len(values), comparisons)
+	}
+	if v, ok := h.Peek(); !ok || v != 0 || s.Raw()[0] != 0 {
+		t.Errorf("expected the least element at the front of the slice, got %v", v)
+	}
+
+	h.Push(-1)
+	reference.Push(-1)
+	if s.Size() != len(values)+1 {
+		t.Errorf("expected Push to append to the slice, got size %d", s.Size())
+	}
+	for !reference.Empty() {
+		if want, got := reference.Pop(), h.Pop(); got != want {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+	if !h.Empty() || !s.Empty() || h.Pop() != nil {
+		t.Error("expected popping all to empty the slice")
+	}
+	if _, ok := h.Peek(); ok {
+		t.Error("expected Peek on an empty heap to fail")
+	}
+}
@@ -0,0 +1,86 @@
+package gods
+
+import (
+	"math"
+	"math/bits"
+)
+
+// HyperLogLog estimates the number of distinct elements of a stream using a
+// fixed amount of memory: 2^precision one-byte registers, for a typical
+// relative error of 1.04/sqrt(2^precision).
+type HyperLogLog struct {
+	precision uint8
+	registers []uint8
+}
+
+// NewHyperLogLog creates an empty HyperLogLog with the given precision.
+// Panics if precision is not in the [4, 16] range.
+func NewHyperLogLog(precision uint8) *HyperLogLog {
+	if precision < 4 || precision > 16 {
+		panic("gods: HyperLogLog precision must be in [4, 16]")
+	}
+	return &HyperLogLog{
+		precision: precision,
+		registers: make([]uint8, 1<<precision),
+	}
+}
+
+// Precision retrieves the precision of HyperLogLog.
+func (h *HyperLogLog) Precision() uint8 {
+	return h.precision
+}
+
+// Add adds data to the stream.
+func (h *HyperLogLog) Add(data []byte) {
+	x := hash64(data)
+	index := x >> (64 - h.precision)
+	// The sentinel bit bounds the rank when the remaining bits are zero.
+	w := x<<h.precision | 1<<(h.precision-1)
+	if rank := uint8(bits.LeadingZeros64(w) + 1); rank > h.registers[index] {
+		h.registers[index] = rank
+	}
+}
+
+// Count returns the estimated number of distinct elements added.
+func (h *HyperLogLog) Count() uint64 {
+	m := float64(len(h.registers))
+	sum, zeros := 0.0, 0
+	for _, r := range h.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	var alpha float64
+	switch len(h.registers) {
+	case 16:
+		alpha = 0.673
+	case 32:
+		alpha = 0.697
+	case 64:
+		alpha = 0.709
+	default:
+		alpha = 0.7213 / (1 + 1.079/m)
+	}
+	estimate := alpha * m * m / sum
+
+	// Small range correction with linear counting.
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(estimate + 0.5)
+}
+
+// Merge combines other into HyperLogLog, which then estimates the number of
+// distinct elements of both streams. Panics if the precisions differ.
+func (h *HyperLogLog) Merge(other *HyperLogLog) {
+	if h.precision != other.precision {
+		panic("gods: can not merge HyperLogLogs of different precisions")
+	}
+	for i, r := range other.registers {
+		if r > h.registers[i] {
+			h.registers[i] = r
+		}
+	}
+}
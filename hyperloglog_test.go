@@ -0,0 +1,52 @@
+package gods
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+func assertCardinality(t *testing.T, h *HyperLogLog, exact int) {
+	t.Helper()
+	// Three standard errors.
+	tolerance := 3 * 1.04 / math.Sqrt(float64(int(1)<<h.Precision()))
+	count := float64(h.Count())
+	if relative := math.Abs(count-float64(exact)) / float64(exact); relative > tolerance {
+		t.Errorf("expected estimate within %.2f%% of %d, got %.0f", 100*tolerance, exact, count)
+	}
+}
+
+func TestHyperLogLog(t *testing.T) {
+	h := NewHyperLogLog(14)
+	if h.Count() != 0 {
+		t.Fatalf("expected empty HyperLogLog to count 0, got %d", h.Count())
+	}
+
+	for _, n := range []int{100, 1000, 100000} {
+		h := NewHyperLogLog(14)
+		for i := 0; i < n; i++ {
+			h.Add([]byte(strconv.Itoa(i)))
+			// Duplicates must not change the estimate.
+			h.Add([]byte(strconv.Itoa(i / 2)))
+		}
+		assertCardinality(t, h, n)
+	}
+}
+
+func TestHyperLogLogMerge(t *testing.T) {
+	a, b := NewHyperLogLog(12), NewHyperLogLog(12)
+	for i := 0; i < 50000; i++ {
+		a.Add([]byte("a" + strconv.Itoa(i)))
+		b.Add([]byte("b" + strconv.Itoa(i)))
+	}
+	assertCardinality(t, a, 50000)
+	a.Merge(b)
+	assertCardinality(t, a, 100000)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic merging different precisions")
+		}
+	}()
+	a.Merge(NewHyperLogLog(10))
+}
@@ -0,0 +1,30 @@
+package gods
+
+import "fmt"
+
+// Intersects reports whether a and b share an element. It ranges over the
+// smaller Set, probing the larger one with Has, and stops at the first
+// common element. A Set must implement KeyRanger to be ranged over, the
+// larger one is ranged over instead if the smaller one does not. Panics if
+// neither does.
+func Intersects(a, b Set) bool {
+	if a.Size() > b.Size() {
+		a, b = b, a
+	}
+	if a.Empty() {
+		return false
+	}
+	r, ok := a.(KeyRanger)
+	if !ok {
+		if r, ok = b.(KeyRanger); !ok {
+			panic(fmt.Sprintf("gods: can not range over %T nor %T", a, b))
+		}
+		a, b = b, a
+	}
+	found := false
+	r.RangeWithKey(func(v interface{}) bool {
+		found = b.Has(v)
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,43 @@
+package gods
+
+import "testing"
+
+// probeCounter counts the calls to Has of the wrapped Set.
+type probeCounter struct {
+	Set
+	probes int
+}
+
+func (p *probeCounter) Has(v interface{}) bool {
+	p.probes++
+	return p.Set.Has(v)
+}
+
+func TestIntersects(t *testing.T) {
+	large := &probeCounter{Set: newTestSet()}
+	for i := 0; i < 1000; i++ {
+		large.Add(i)
+	}
+	if !Intersects(newTestSet(-1, -2, 500), large) {
+		t.Error("expected overlapping sets to intersect")
+	}
+	if large.probes > 3 {
+		t.Errorf("expected the smaller set to be ranged over, got %d probes", large.probes)
+	}
+
+	large.probes = 0
+	if !Intersects(large, newTestSet(7, 8, 9)) || large.probes != 1 {
+		t.Errorf("expected to stop at the first match, got %d probes", large.probes)
+	}
+	if Intersects(newTestSet(-1, -2), large) {
+		t.Error("expected disjoint sets not to intersect")
+	}
+	if Intersects(newTestSet(), large) || Intersects(large, newTestSet()) {
+		t.Error("expected an empty set not to intersect")
+	}
+
+	words := NewComparerSet(caseInsensitive("Go"), caseInsensitive("Rust"))
+	if !Intersects(words, NewComparerSet(caseInsensitive("go"))) {
+		t.Error("expected ComparerSets to intersect by Compare")
+	}
+}
@@ -0,0 +1,64 @@
+package gods
+
+// CollectN returns the first n elements yielded by it, or fewer if it is
+// exhausted before. It is the way to consume infinite Iterators.
+func CollectN(it Iterator, n int) []interface{} {
+	var values []interface{}
+	for len(values) < n && it.Next() {
+		values = append(values, it.Value())
+	}
+	return values
+}
+
+// NewCycleIterator returns an Iterator yielding the elements of s in order,
+// wrapping to the first one after the last one forever, for Slice
+// implementations to return from Cycle. It reads s as it goes, and yields
+// nothing once s is empty.
+func NewCycleIterator(s Slice) Iterator {
+	return &cycleIterator{s: s, i: -1}
+}
+
+type cycleIterator struct {
+	s Slice
+	i int
+}
+
+func (it *cycleIterator) Next() bool {
+	size := it.s.Size()
+	if size == 0 {
+		return false
+	}
+	it.i = (it.i + 1) % size
+	return true
+}
+
+func (it *cycleIterator) Value() interface{} {
+	return it.s.Raw()[it.i]
+}
+
+// ZipIterators returns an Iterator advancing a and b in lockstep, and
+// yielding combine called with their values. It is exhausted as soon as
+// either a or b is, and does not advance the other one any further.
+func ZipIterators(a, b Iterator, combine func(x, y interface{}) interface{}) Iterator {
+	return &zipIterator{a: a, b: b, combine: combine}
+}
+
+type zipIterator struct {
+	a, b    Iterator
+	combine func(x, y interface{}) interface{}
+	value   interface{}
+	done    bool
+}
+
+func (it *zipIterator) Next() bool {
+	if it.done || !it.a.Next() || !it.b.Next() {
+		it.done, it.value = true, nil
+		return false
+	}
+	it.value = it.combine(it.a.Value(), it.b.Value())
+	return true
+}
+
+func (it *zipIterator) Value() interface{} {
+	return it.value
+}
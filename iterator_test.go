@@ -0,0 +1,109 @@
+package gods
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCollectN(t *testing.T) {
+	s := NewArraySlice("a", "b", "c")
+	want := []interface{}{"a", "b", "c", "a", "b", "c", "a"}
+	if got := CollectN(s.Cycle(), 7); !equalRaw(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got := CollectN(s.Cycle(), 0); len(got) != 0 {
+		t.Errorf("expected nothing collected, got %v", got)
+	}
+	if got := CollectN(NewArraySlice().Cycle(), 7); len(got) != 0 {
+		t.Errorf("expected cycling an empty slice to yield nothing, got %v", got)
+	}
+}
+
+func TestNewCycleIterator(t *testing.T) {
+	s := NewArraySlice(1, 2, 3)
+	it := NewCycleIterator(s)
+	if got := CollectN(it, 4); !equalRaw(got, []interface{}{1, 2, 3, 1}) {
+		t.Errorf("expected [1 2 3 1], got %v", got)
+	}
+	// The Slice is read as the Iterator goes.
+	s.Pop()
+	if got := CollectN(it, 3); !equalRaw(got, []interface{}{2, 1, 2}) {
+		t.Errorf("expected [2 1 2] after popping 3, got %v", got)
+	}
+	s.Clear()
+	if it.Next() {
+		t.Error("expected the Iterator to stop once the Slice is empty")
+	}
+}
+
+// sliceIterator is a finite Iterator over values, counting the calls to
+// Next.
+type sliceIterator struct {
+	values []interface{}
+	i      int
+	nexts  int
+}
+
+func iterate(values ...interface{}) *sliceIterator {
+	return &sliceIterator{values: values, i: -1}
+}
+
+func (it *sliceIterator) Next() bool {
+	it.nexts++
+	if it.i+1 >= len(it.values) {
+		return false
+	}
+	it.i++
+	return true
+}
+
+func (it *sliceIterator) Value() interface{} { return it.values[it.i] }
+
+// inOrder returns an Iterator over the elements of t in ascending order.
+func inOrder(t *OrderStatisticTree) Iterator {
+	var values []interface{}
+	t.RangeWithKey(func(v interface{}) bool {
+		values = append(values, v)
+		return true
+	})
+	return iterate(values...)
+}
+
+func TestZipIterators(t *testing.T) {
+	tree := NewOrderStatisticTree(intCompare)
+	for _, v := range []int{30, 10, 20} {
+		tree.Insert(v)
+	}
+	pair := func(x, y interface{}) interface{} { return fmt.Sprint(x, ":", y) }
+
+	got := CollectN(ZipIterators(iterate("a", "b", "c"), inOrder(tree), pair), 10)
+	if want := []interface{}{"a:10", "b:20", "c:30"}; !equalRaw(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	// An infinite Iterator is zipped up to the end of the finite one.
+	got = CollectN(ZipIterators(NewArraySlice(0, 1).Cycle(), inOrder(tree), pair), 10)
+	if want := []interface{}{"0:10", "1:20", "0:30"}; !equalRaw(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestZipIteratorsDifferentLengths(t *testing.T) {
+	sum := func(x, y interface{}) interface{} { return x.(int) + y.(int) }
+	short, long := iterate(1, 2), iterate(10, 20, 30, 40)
+	it := ZipIterators(long, short, sum)
+	got := CollectN(it, 10)
+	if want := []interface{}{11, 22}; !equalRaw(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if it.Next() {
+		t.Error("expected an exhausted zip to stay exhausted")
+	}
+	if long.i != 2 || short.nexts != 3 {
+		t.Errorf("expected the iterators not to be advanced once exhausted, got %d and %d", long.i, short.nexts)
+	}
+
+	if got := CollectN(ZipIterators(iterate(), iterate(1), sum), 10); len(got) != 0 {
+		t.Errorf("expected zipping an empty Iterator to yield nothing, got %v", got)
+	}
+}
@@ -0,0 +1,35 @@
+package gods
+
+import "fmt"
+
+// LexicographicComparer returns a comparison function of Slices or
+// []interface{}, comparing them element by element with elemCmp. The first
+// differing elements decide the order, and a Slice which is a prefix of the
+// other sorts first. The returned function panics on other types.
+func LexicographicComparer(elemCmp func(a, b interface{}) int) func(a, b interface{}) int {
+	return func(a, b interface{}) int {
+		x, y := lexicographicRaw(a), lexicographicRaw(b)
+		for i := 0; i < len(x) && i < len(y); i++ {
+			if c := elemCmp(x[i], y[i]); c != 0 {
+				return c
+			}
+		}
+		switch {
+		case len(x) < len(y):
+			return -1
+		case len(x) > len(y):
+			return 1
+		}
+		return 0
+	}
+}
+
+func lexicographicRaw(v interface{}) []interface{} {
+	switch s := v.(type) {
+	case Slice:
+		return s.Raw()
+	case []interface{}:
+		return s
+	}
+	panic(fmt.Sprintf("gods: can not compare %T lexicographically", v))
+}
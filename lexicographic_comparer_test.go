@@ -0,0 +1,50 @@
+package gods
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+)
+
+func TestLexicographicComparer(t *testing.T) {
+	cmp := LexicographicComparer(intCompare)
+	tests := []struct {
+		a, b interface{}
+		want int
+	}{
+		{[]interface{}{1, 2}, []interface{}{1, 2, 3}, -1},
+		{[]interface{}{1, 2, 3}, []interface{}{1, 2}, 1},
+		{[]interface{}{1, 3}, []interface{}{1, 2}, 1},
+		{[]interface{}{1, 2}, []interface{}{1, 3}, -1},
+		{[]interface{}{1, 2}, []interface{}{1, 2}, 0},
+		{[]interface{}{}, []interface{}{}, 0},
+		{[]interface{}{}, []interface{}{0}, -1},
+		{NewArraySlice(1, 2), []interface{}{1, 2}, 0},
+		{NewArraySlice(2), NewArraySlice(1, 9), 1},
+	}
+	for _, tt := range tests {
+		if got := cmp(tt.a, tt.b); got != tt.want {
+			t.Errorf("cmp(%v, %v) = %d, expected %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+
+	if !panics(func() { cmp([]int{1}, []interface{}{1}) }) {
+		t.Error("expected comparing a []int to panic")
+	}
+}
+
+func TestLexicographicComparerSort(t *testing.T) {
+	cmp := LexicographicComparer(intCompare)
+	slices := []interface{}{
+		[]interface{}{2},
+		[]interface{}{1, 2, 3},
+		[]interface{}{},
+		[]interface{}{1, 2},
+		[]interface{}{1, 3},
+	}
+	sort.Slice(slices, func(i, j int) bool { return cmp(slices[i], slices[j]) < 0 })
+	want := "[[] [1 2] [1 2 3] [1 3] [2]]"
+	if got := fmt.Sprint(slices); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
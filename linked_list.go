@@ -0,0 +1,223 @@
+package gods
+
+// LinkedList is a doubly linked list. Adding and removing at both ends is
+// O(1), so it can serve as a stack with AddFirst and RemoveFirst, as a queue
+// with AddLast and RemoveFirst, or as a Deque. Positional access is O(n),
+// walking from the nearest end.
+type LinkedList struct {
+	head *listNode
+	tail *listNode
+	size int
+}
+
+type listNode struct {
+	value      interface{}
+	prev, next *listNode
+}
+
+var (
+	_ Deque       = (*LinkedList)(nil)
+	_ Peeker      = (*LinkedList)(nil)
+	_ IndexRanger = (*LinkedList)(nil)
+)
+
+// NewLinkedList creates a LinkedList with the given elements, in order.
+func NewLinkedList(values ...interface{}) *LinkedList {
+	l := &LinkedList{}
+	for _, v := range values {
+		l.AddLast(v)
+	}
+	return l
+}
+
+// Empty indicates if the LinkedList is empty.
+func (l *LinkedList) Empty() bool {
+	return l.size == 0
+}
+
+// Size retrieves LinkedList size.
+func (l *LinkedList) Size() int {
+	return l.size
+}
+
+// Clear resets LinkedList, it will be empty with size 0.
+func (l *LinkedList) Clear() {
+	l.head, l.tail, l.size = nil, nil, 0
+}
+
+// Peek inspects the first element of LinkedList without removing it.
+// Returns (nil, false) if the LinkedList is empty.
+func (l *LinkedList) Peek() (interface{}, bool) {
+	return l.PeekFront()
+}
+
+// RangeWithIndex iterates LinkedList from the first to the last element.
+// Stop iterating if the IndexRangerFunc returns false.
+func (l *LinkedList) RangeWithIndex(fn IndexRangerFunc) {
+	i := 0
+	for n := l.head; n != nil; n = n.next {
+		if !fn(i, n.value) {
+			return
+		}
+		i++
+	}
+}
+
+// AddFirst inserts an element at the start of LinkedList.
+func (l *LinkedList) AddFirst(v interface{}) {
+	l.insertBefore(l.head, v)
+}
+
+// AddLast appends an element to the end of LinkedList.
+func (l *LinkedList) AddLast(v interface{}) {
+	l.insertBefore(nil, v)
+}
+
+// RemoveFirst removes the first element of LinkedList and returns it.
+// Returns (nil, false) if the LinkedList is empty.
+func (l *LinkedList) RemoveFirst() (interface{}, bool) {
+	if l.head == nil {
+		return nil, false
+	}
+	return l.remove(l.head), true
+}
+
+// RemoveLast removes the last element of LinkedList and returns it.
+// Returns (nil, false) if the LinkedList is empty.
+func (l *LinkedList) RemoveLast() (interface{}, bool) {
+	if l.tail == nil {
+		return nil, false
+	}
+	return l.remove(l.tail), true
+}
+
+// Get returns the element at index. Returns (nil, false) if index is out
+// of range.
+func (l *LinkedList) Get(index int) (interface{}, bool) {
+	n := l.nodeAt(index)
+	if n == nil {
+		return nil, false
+	}
+	return n.value, true
+}
+
+// InsertAt inserts an element at index, shifting the following elements.
+// An index equal to Size appends the element. Panics if index is out of
+// the [0, Size] range.
+func (l *LinkedList) InsertAt(index int, v interface{}) {
+	if index == l.size {
+		l.AddLast(v)
+		return
+	}
+	n := l.nodeAt(index)
+	if n == nil {
+		panic("gods: LinkedList index out of range")
+	}
+	l.insertBefore(n, v)
+}
+
+// RemoveAt removes the element at index and returns it. Returns
+// (nil, false) if index is out of range.
+func (l *LinkedList) RemoveAt(index int) (interface{}, bool) {
+	n := l.nodeAt(index)
+	if n == nil {
+		return nil, false
+	}
+	return l.remove(n), true
+}
+
+// PushFront inserts an element at the start of LinkedList.
+func (l *LinkedList) PushFront(v interface{}) {
+	l.AddFirst(v)
+}
+
+// PopFront ejects the first element of LinkedList and removes it.
+// Returns nil if the LinkedList is empty.
+func (l *LinkedList) PopFront() interface{} {
+	v, _ := l.RemoveFirst()
+	return v
+}
+
+// PeekFront inspects the first element of LinkedList without removing it.
+// Returns (nil, false) if the LinkedList is empty.
+func (l *LinkedList) PeekFront() (interface{}, bool) {
+	if l.head == nil {
+		return nil, false
+	}
+	return l.head.value, true
+}
+
+// PushBack appends an element to the end of LinkedList.
+func (l *LinkedList) PushBack(v interface{}) {
+	l.AddLast(v)
+}
+
+// PopBack ejects the last element of LinkedList and removes it.
+// Returns nil if the LinkedList is empty.
+func (l *LinkedList) PopBack() interface{} {
+	v, _ := l.RemoveLast()
+	return v
+}
+
+// PeekBack inspects the last element of LinkedList without removing it.
+// Returns (nil, false) if the LinkedList is empty.
+func (l *LinkedList) PeekBack() (interface{}, bool) {
+	if l.tail == nil {
+		return nil, false
+	}
+	return l.tail.value, true
+}
+
+// nodeAt returns the node at index, or nil if index is out of range.
+func (l *LinkedList) nodeAt(index int) *listNode {
+	if index < 0 || index >= l.size {
+		return nil
+	}
+	if index < l.size/2 {
+		n := l.head
+		for i := 0; i < index; i++ {
+			n = n.next
+		}
+		return n
+	}
+	n := l.tail
+	for i := l.size - 1; i > index; i-- {
+		n = n.prev
+	}
+	return n
+}
+
+// insertBefore inserts v before the node at, or at the end if at is nil.
+func (l *LinkedList) insertBefore(at *listNode, v interface{}) {
+	n := &listNode{value: v, next: at}
+	if at == nil {
+		n.prev = l.tail
+		l.tail = n
+	} else {
+		n.prev = at.prev
+		at.prev = n
+	}
+	if n.prev == nil {
+		l.head = n
+	} else {
+		n.prev.next = n
+	}
+	l.size++
+}
+
+func (l *LinkedList) remove(n *listNode) interface{} {
+	if n.prev == nil {
+		l.head = n.next
+	} else {
+		n.prev.next = n.next
+	}
+	if n.next == nil {
+		l.tail = n.prev
+	} else {
+		n.next.prev = n.prev
+	}
+	l.size--
+	v := n.value
+	*n = listNode{}
+	return v
+}
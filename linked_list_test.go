@@ -0,0 +1,141 @@
+package gods
+
+import "testing"
+
+func linkedListValues(l *LinkedList) []interface{} {
+	var values []interface{}
+	l.RangeWithIndex(func(_ int, v interface{}) bool {
+		values = append(values, v)
+		return true
+	})
+	// Walk backward too, to check the prev links.
+	i := len(values) - 1
+	for n := l.tail; n != nil; n = n.prev {
+		if i < 0 || values[i] != n.value {
+			panic("inconsistent backward links")
+		}
+		i--
+	}
+	return values
+}
+
+func TestLinkedListAsStack(t *testing.T) {
+	l := NewLinkedList()
+	if !l.Empty() {
+		t.Fatal("expected new list to be empty")
+	}
+	if _, ok := l.RemoveFirst(); ok {
+		t.Error("expected RemoveFirst on empty list to fail")
+	}
+	for i := 0; i < 3; i++ {
+		l.AddFirst(i)
+	}
+	if v, ok := l.Peek(); !ok || v != 2 {
+		t.Errorf("expected top 2, got %v", v)
+	}
+	for _, want := range []int{2, 1, 0} {
+		if v, ok := l.RemoveFirst(); !ok || v != want {
+			t.Errorf("expected %d, got %v", want, v)
+		}
+	}
+	if !l.Empty() || l.head != nil || l.tail != nil {
+		t.Error("expected list to be empty after removing all")
+	}
+}
+
+func TestLinkedListAsQueue(t *testing.T) {
+	l := NewLinkedList()
+	for i := 0; i < 3; i++ {
+		l.AddLast(i)
+	}
+	for _, want := range []int{0, 1, 2} {
+		if v, ok := l.RemoveFirst(); !ok || v != want {
+			t.Errorf("expected %d, got %v", want, v)
+		}
+	}
+}
+
+func TestLinkedListAsDeque(t *testing.T) {
+	var d Deque = NewLinkedList()
+	d.PushBack(2)
+	d.PushFront(1)
+	d.PushBack(3)
+	if v, _ := d.PeekFront(); v != 1 {
+		t.Errorf("expected front 1, got %v", v)
+	}
+	if v, _ := d.PeekBack(); v != 3 {
+		t.Errorf("expected back 3, got %v", v)
+	}
+	if d.PopBack() != 3 || d.PopFront() != 1 || d.PopFront() != 2 {
+		t.Error("expected elements from both ends")
+	}
+	if d.PopFront() != nil || d.PopBack() != nil {
+		t.Error("expected nil from an empty deque")
+	}
+	if _, ok := d.PeekBack(); ok {
+		t.Error("expected PeekBack on empty deque to fail")
+	}
+}
+
+func TestLinkedListPositional(t *testing.T) {
+	l := NewLinkedList("a", "b", "c", "d", "e")
+	for i, want := range []string{"a", "b", "c", "d", "e"} {
+		if v, ok := l.Get(i); !ok || v != want {
+			t.Errorf("expected %s at %d, got %v", want, i, v)
+		}
+	}
+	for _, i := range []int{-1, 5} {
+		if _, ok := l.Get(i); ok {
+			t.Errorf("expected Get(%d) to fail", i)
+		}
+		if _, ok := l.RemoveAt(i); ok {
+			t.Errorf("expected RemoveAt(%d) to fail", i)
+		}
+	}
+
+	l.InsertAt(0, "start")
+	l.InsertAt(3, "middle")
+	l.InsertAt(l.Size(), "end")
+	want := []interface{}{"start", "a", "b", "middle", "c", "d", "e", "end"}
+	if got := linkedListValues(l); !equalRaw(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	for _, tt := range []struct {
+		index int
+		value string
+	}{{3, "middle"}, {0, "start"}, {5, "end"}, {2, "c"}} {
+		if v, ok := l.RemoveAt(tt.index); !ok || v != tt.value {
+			t.Errorf("expected RemoveAt(%d) to return %s, got %v", tt.index, tt.value, v)
+		}
+	}
+	want = []interface{}{"a", "b", "d", "e"}
+	if got := linkedListValues(l); !equalRaw(got, want) || l.Size() != 4 {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	var visited []interface{}
+	l.RangeWithIndex(func(i int, v interface{}) bool {
+		visited = append(visited, v)
+		return i < 1
+	})
+	if !equalRaw(visited, []interface{}{"a", "b"}) {
+		t.Errorf("expected early stop, got %v", visited)
+	}
+
+	for _, i := range []int{-1, 5} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected InsertAt(%d) to panic", i)
+				}
+			}()
+			l.InsertAt(i, "x")
+		}()
+	}
+
+	l.Clear()
+	if !l.Empty() || len(linkedListValues(l)) != 0 {
+		t.Error("expected list to be empty after Clear")
+	}
+}
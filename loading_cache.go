@@ -0,0 +1,161 @@
+package gods
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+// LoadingCache is a bounded read-through cache safe for concurrent use. Get
+// returns the cached value of a key, or loads it with the loader on a miss.
+// Concurrent misses on the same key share a single call to the loader. When
+// the size exceeds its capacity, the least recently used entry is evicted.
+type LoadingCache struct {
+	mu      sync.Mutex
+	loader  func(key interface{}) (interface{}, error)
+	maxSize int
+	entries map[interface{}]*list.Element // of *loadingEntry
+	lru     *list.List                    // most recently used first
+	calls   map[interface{}]*loadCall
+}
+
+type loadingEntry struct {
+	key, value interface{}
+}
+
+// loadCall is an in flight call to the loader, waited for by the Gets
+// missing the same key.
+type loadCall struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+// NewLoadingCache creates an empty LoadingCache loading missing values with
+// loader, and holding at most maxSize entries. Panics if maxSize is not
+// positive.
+func NewLoadingCache(loader func(key interface{}) (interface{}, error), maxSize int) *LoadingCache {
+	if maxSize <= 0 {
+		panic("gods: LoadingCache maxSize must be positive")
+	}
+	return &LoadingCache{
+		loader:  loader,
+		maxSize: maxSize,
+		entries: make(map[interface{}]*list.Element),
+		lru:     list.New(),
+		calls:   make(map[interface{}]*loadCall),
+	}
+}
+
+// Empty indicates if the LoadingCache is empty.
+func (c *LoadingCache) Empty() bool {
+	return c.Size() == 0
+}
+
+// Size retrieves the number of cached entries.
+func (c *LoadingCache) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Clear resets LoadingCache, it will be empty with size 0. Loads in flight
+// still complete and cache their value.
+func (c *LoadingCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[interface{}]*list.Element)
+	c.lru.Init()
+}
+
+// Get returns the value cached for key, marking it as the most recently
+// used. On a miss, it calls the loader, or waits for the call already in
+// flight for key, and caches the loaded value. Errors of the loader are
+// returned and not cached.
+func (c *LoadingCache) Get(key interface{}) (interface{}, error) {
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(e)
+		c.mu.Unlock()
+		return e.Value.(*loadingEntry).value, nil
+	}
+	if call, ok := c.calls[key]; ok {
+		c.mu.Unlock()
+		<-call.done
+		return call.value, call.err
+	}
+	call := &loadCall{done: make(chan struct{})}
+	c.calls[key] = call
+	c.mu.Unlock()
+
+	c.load(key, call)
+	return call.value, call.err
+}
+
+// load calls the loader for key and completes call. If the loader panics,
+// the waiting callers get an error, and the panic is propagated once call
+// is completed so that later calls load key again.
+func (c *LoadingCache) load(key interface{}, call *loadCall) {
+	returned := false
+	defer func() {
+		var r interface{}
+		if !returned {
+			r = recover()
+			call.value, call.err = nil, fmt.Errorf("gods: LoadingCache loader panicked: %v", r)
+		}
+		c.mu.Lock()
+		delete(c.calls, key)
+		// A value Put during the load is fresher than the loaded one.
+		if _, ok := c.entries[key]; !ok && call.err == nil {
+			c.put(key, call.value)
+		}
+		c.mu.Unlock()
+		close(call.done)
+		if !returned {
+			panic(r)
+		}
+	}()
+	call.value, call.err = c.loader(key)
+	returned = true
+}
+
+// Has checks whether a value is cached for key, without loading it nor
+// marking it as used.
+func (c *LoadingCache) Has(key interface{}) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.entries[key]
+	return ok
+}
+
+// Put caches value for key as the most recently used entry, evicting the
+// least recently used one if the capacity is exceeded.
+func (c *LoadingCache) Put(key, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.put(key, value)
+}
+
+func (c *LoadingCache) put(key, value interface{}) {
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*loadingEntry).value = value
+		c.lru.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&loadingEntry{key: key, value: value})
+	if c.lru.Len() > c.maxSize {
+		oldest := c.lru.Remove(c.lru.Back()).(*loadingEntry)
+		delete(c.entries, oldest.key)
+	}
+}
+
+// Delete removes the value cached for key, so that the next Get loads it
+// again.
+func (c *LoadingCache) Delete(key interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.lru.Remove(e)
+		delete(c.entries, key)
+	}
+}
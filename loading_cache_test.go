@@ -0,0 +1,128 @@
+package gods
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLoadingCacheSingleFlight(t *testing.T) {
+	var loads int64
+	c := NewLoadingCache(func(key interface{}) (interface{}, error) {
+		atomic.AddInt64(&loads, 1)
+		time.Sleep(20 * time.Millisecond)
+		return key.(int) * 2, nil
+	}, 10)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := c.Get(21); err != nil || v != 42 {
+				t.Errorf("expected 42, got %v, %v", v, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := atomic.LoadInt64(&loads); n != 1 {
+		t.Errorf("expected the loader to run once, got %d", n)
+	}
+	if !c.Has(21) || c.Size() != 1 {
+		t.Error("expected the loaded value to be cached")
+	}
+}
+
+func TestLoadingCache(t *testing.T) {
+	errNotFound := errors.New("not found")
+	var loads []interface{}
+	c := NewLoadingCache(func(key interface{}) (interface{}, error) {
+		loads = append(loads, key)
+		if key == "missing" {
+			return nil, errNotFound
+		}
+		return key.(string) + "!", nil
+	}, 2)
+
+	for _, key := range []string{"a", "b", "a"} {
+		if v, err := c.Get(key); err != nil || v != key+"!" {
+			t.Errorf("expected %s!, got %v, %v", key, v, err)
+		}
+	}
+	// a was used more recently than b, which is evicted.
+	c.Put("c", "put")
+	if c.Has("b") || !c.Has("a") || !c.Has("c") {
+		t.Error("expected the least recently used entry to be evicted")
+	}
+	if v, _ := c.Get("c"); v != "put" {
+		t.Errorf("expected the put value, got %v", v)
+	}
+
+	if _, err := c.Get("missing"); !errors.Is(err, errNotFound) {
+		t.Errorf("expected the loader error, got %v", err)
+	}
+	c.Get("missing")
+	if c.Has("missing") {
+		t.Error("expected errors not to be cached")
+	}
+
+	c.Delete("a")
+	c.Get("a")
+	if !equalRaw(loads, []interface{}{"a", "b", "missing", "missing", "a"}) {
+		t.Errorf("unexpected loads %v", loads)
+	}
+
+	c.Clear()
+	if !c.Empty() {
+		t.Error("expected cache to be empty after Clear")
+	}
+}
+
+func TestLoadingCacheLoaderPanic(t *testing.T) {
+	release := make(chan struct{})
+	var loads int64
+	c := NewLoadingCache(func(key interface{}) (interface{}, error) {
+		if atomic.AddInt64(&loads, 1) == 1 {
+			<-release
+			panic("boom")
+		}
+		return "loaded", nil
+	}, 10)
+
+	recovered := make(chan interface{})
+	go func() {
+		defer func() { recovered <- recover() }()
+		c.Get("k")
+	}()
+	// Let the first call start loading before a second one waits on it.
+	time.Sleep(20 * time.Millisecond)
+	waited := make(chan error)
+	go func() {
+		_, err := c.Get("k")
+		waited <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	if r := <-recovered; r != "boom" {
+		t.Errorf("expected the loader panic to propagate to its caller, got %v", r)
+	}
+	if err := <-waited; err == nil {
+		t.Error("expected the waiting caller to get an error")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if v, err := c.Get("k"); v != "loaded" || err != nil {
+			t.Errorf("expected key to be loaded again, got (%v, %v)", v, err)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected Get not to block after a loader panic")
+	}
+}
@@ -0,0 +1,31 @@
+package gods
+
+import "unicode/utf8"
+
+// LongestCommonPrefix returns the longest prefix shared by all the strings
+// of the Slice, never splitting a multi-byte rune. Returns the empty string
+// if s is empty or the strings have no common prefix.
+// Panics if an element of s is not a string.
+func LongestCommonPrefix(s Slice) string {
+	raw := s.Raw()
+	if len(raw) == 0 {
+		return ""
+	}
+	prefix := raw[0].(string)
+	for _, v := range raw[1:] {
+		str := v.(string)
+		n := 0
+		for n < len(prefix) && n < len(str) && prefix[n] == str[n] {
+			n++
+		}
+		// Back off to the start of a rune the strings disagree on.
+		for n > 0 && n < len(prefix) && !utf8.RuneStart(prefix[n]) {
+			n--
+		}
+		prefix = prefix[:n]
+		if prefix == "" {
+			break
+		}
+	}
+	return prefix
+}
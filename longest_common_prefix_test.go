@@ -0,0 +1,25 @@
+package gods
+
+import "testing"
+
+func TestLongestCommonPrefix(t *testing.T) {
+	tests := []struct {
+		strings *ArraySlice
+		want    string
+	}{
+		{NewArraySlice(), ""},
+		{NewArraySlice("alone"), "alone"},
+		{NewArraySlice("flower", "flow", "flight"), "fl"},
+		{NewArraySlice("interview", "internet", "interval", "internal"), "inter"},
+		{NewArraySlice("dog", "racecar", "car"), ""},
+		{NewArraySlice("same", "same"), "same"},
+		{NewArraySlice("prefix", "pre", ""), ""},
+		// é and è share their first byte, which must not be kept.
+		{NewArraySlice("café", "cafè"), "caf"},
+	}
+	for _, tt := range tests {
+		if got := LongestCommonPrefix(tt.strings); got != tt.want {
+			t.Errorf("LongestCommonPrefix(%q) = %q, expected %q", tt.strings.Raw(), got, tt.want)
+		}
+	}
+}
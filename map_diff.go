@@ -0,0 +1,33 @@
+package gods
+
+import "reflect"
+
+// Diff compares the Maps old and new, which are left unchanged. added holds
+// the (key,value) pairs of new whose key is not in old, removed holds the
+// pairs of old whose key is not in new, and changed holds the pairs of new
+// whose key is also in old but bound to a different value there. added and
+// changed are built with FilterKeys and FilterEntries on new, removed with
+// FilterKeys on old, so each has the type of the Map it comes from.
+//
+// Values of the same type implementing Comparer are equal when Compare
+// returns 0, as keys are in ComparerMap, other values are compared with ==.
+func Diff(old, new Map) (added, removed, changed Map) {
+	added = new.FilterKeys(func(key interface{}) bool { return !old.Has(key) })
+	removed = old.FilterKeys(func(key interface{}) bool { return !new.Has(key) })
+	changed = new.FilterEntries(func(key, value interface{}) bool {
+		previous, ok := old.Get(key)
+		return ok && !valuesEqual(previous, value)
+	})
+	return added, removed, changed
+}
+
+// valuesEqual reports whether a and b are equal, using Compare when both
+// are Comparers of the same type and == otherwise.
+func valuesEqual(a, b interface{}) bool {
+	ca, okA := a.(Comparer)
+	cb, okB := b.(Comparer)
+	if okA && okB && reflect.TypeOf(a) == reflect.TypeOf(b) {
+		return ca.Compare(cb) == 0
+	}
+	return a == b
+}
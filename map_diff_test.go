@@ -0,0 +1,43 @@
+package gods
+
+import "testing"
+
+func TestDiff(t *testing.T) {
+	old := NewShardedMap()
+	old.Add("kept", 1).Add("gone", 2).Add("bumped", 3).Add("cased", caseInsensitive("abc"))
+	new := NewShardedMap()
+	new.Add("kept", 1).Add("fresh", 4).Add("bumped", 5).Add("cased", caseInsensitive("ABC"))
+
+	added, removed, changed := Diff(old, new)
+	if added.Size() != 1 {
+		t.Errorf("added has %d pairs, want 1", added.Size())
+	}
+	if v, ok := added.Get("fresh"); !ok || v != 4 {
+		t.Errorf("added[fresh] = %v, %v, want 4, true", v, ok)
+	}
+	if removed.Size() != 1 {
+		t.Errorf("removed has %d pairs, want 1", removed.Size())
+	}
+	if v, ok := removed.Get("gone"); !ok || v != 2 {
+		t.Errorf("removed[gone] = %v, %v, want 2, true", v, ok)
+	}
+	if changed.Size() != 1 {
+		t.Errorf("changed has %d pairs, want 1", changed.Size())
+	}
+	if v, ok := changed.Get("bumped"); !ok || v != 5 {
+		t.Errorf("changed[bumped] = %v, %v, want 5, true", v, ok)
+	}
+	if old.Size() != 4 || new.Size() != 4 {
+		t.Errorf("Diff modified its inputs: sizes %d and %d", old.Size(), new.Size())
+	}
+}
+
+func TestDiffIdentical(t *testing.T) {
+	m := NewShardedMap()
+	m.Add(1, "a").Add(2, "b").Add(3, Int(3))
+	added, removed, changed := Diff(m, m.Clone())
+	if !added.Empty() || !removed.Empty() || !changed.Empty() {
+		t.Errorf("Diff of identical Maps = %d, %d, %d pairs, want none",
+			added.Size(), removed.Size(), changed.Size())
+	}
+}
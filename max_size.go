@@ -0,0 +1,110 @@
+package gods
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrContainerFull is the panic value, possibly wrapped, of adding an
+// element to a Container wrapped by WithMaxSize which is already full.
+var ErrContainerFull = errors.New("gods: container is full")
+
+// WithMaxSize wraps c so that adding an element which would grow it beyond
+// max elements panics with ErrContainerFull, instead of growing without
+// bound. Adds which do not grow c, like overwriting a key of a Map, always
+// succeed.
+//
+// The returned Container implements the same abstract data structure as c,
+// which is looked up in order among Queue (which covers Stack,
+// PriorityQueue and MonotoneQueue), Deque, Set and Map. Changes made to c
+// directly bypass the guard. Panics if c implements none of them.
+func WithMaxSize(c Container, max int) Container {
+	switch c := c.(type) {
+	case Queue:
+		return &maxSizeQueue{c, max}
+	case Deque:
+		return &maxSizeDeque{c, max}
+	case Set:
+		return &maxSizeSet{c, max}
+	case Map:
+		return &maxSizeMap{c, max}
+	}
+	panic(fmt.Sprintf("gods: WithMaxSize does not support %T", c))
+}
+
+func containerFull(max int) error {
+	return fmt.Errorf("%w: %d elements at most", ErrContainerFull, max)
+}
+
+type maxSizeQueue struct {
+	Queue
+	max int
+}
+
+func (q *maxSizeQueue) Push(v interface{}) {
+	if q.Size() >= q.max {
+		panic(containerFull(q.max))
+	}
+	q.Queue.Push(v)
+}
+
+type maxSizeDeque struct {
+	Deque
+	max int
+}
+
+func (d *maxSizeDeque) PushFront(v interface{}) {
+	if d.Size() >= d.max {
+		panic(containerFull(d.max))
+	}
+	d.Deque.PushFront(v)
+}
+
+func (d *maxSizeDeque) PushBack(v interface{}) {
+	if d.Size() >= d.max {
+		panic(containerFull(d.max))
+	}
+	d.Deque.PushBack(v)
+}
+
+type maxSizeSet struct {
+	Set
+	max int
+}
+
+// Add adds the values in order, asking the wrapped Set whether each is
+// present, so that values it considers equal count once. It panics at the
+// first missing value which does not fit, keeping the ones added before.
+func (s *maxSizeSet) Add(values ...interface{}) Set {
+	for _, v := range values {
+		if !s.Has(v) && s.Size() >= s.max {
+			panic(containerFull(s.max))
+		}
+		s.Set.Add(v)
+	}
+	return s
+}
+
+type maxSizeMap struct {
+	Map
+	max int
+}
+
+func (m *maxSizeMap) Add(key, value interface{}) Map {
+	if !m.Has(key) && m.Size() >= m.max {
+		panic(containerFull(m.max))
+	}
+	m.Map.Add(key, value)
+	return m
+}
+
+func (m *maxSizeMap) Clone() Map {
+	return &maxSizeMap{m.Map.Clone(), m.max}
+}
+
+func (m *maxSizeMap) ComputeIfAbsent(key interface{}, supplier func() interface{}) interface{} {
+	if !m.Has(key) && m.Size() >= m.max {
+		panic(containerFull(m.max))
+	}
+	return m.Map.ComputeIfAbsent(key, supplier)
+}
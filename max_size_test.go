@@ -0,0 +1,120 @@
+package gods
+
+import (
+	"errors"
+	"testing"
+)
+
+// expectFull checks that fn panics with ErrContainerFull.
+func expectFull(t *testing.T, name string, fn func()) {
+	t.Helper()
+	defer func() {
+		t.Helper()
+		err, _ := recover().(error)
+		if !errors.Is(err, ErrContainerFull) {
+			t.Errorf("expected %s to panic with ErrContainerFull, got %v", name, err)
+		}
+	}()
+	fn()
+}
+
+func TestWithMaxSizeQueue(t *testing.T) {
+	q := WithMaxSize(NewConcurrentQueue(), 3).(Queue)
+	for i := 0; i < 3; i++ {
+		q.Push(i)
+	}
+	expectFull(t, "Push", func() { q.Push(3) })
+	if q.Size() != 3 {
+		t.Errorf("expected size 3, got %d", q.Size())
+	}
+	q.Pop()
+	q.Push(3)
+	if v, _ := q.Peek(); v != 1 {
+		t.Errorf("expected 1 at the front, got %v", v)
+	}
+}
+
+func TestWithMaxSizeDeque(t *testing.T) {
+	d := WithMaxSize(NewLinkedList(), 2).(Deque)
+	d.PushFront(1)
+	d.PushBack(2)
+	expectFull(t, "PushFront", func() { d.PushFront(0) })
+	expectFull(t, "PushBack", func() { d.PushBack(3) })
+	if v := d.PopBack(); v != 2 {
+		t.Errorf("expected 2, got %v", v)
+	}
+	d.PushBack(3)
+}
+
+func TestWithMaxSizeSet(t *testing.T) {
+	s := WithMaxSize(newTestSet(), 3).(Set)
+	s.Add(1, 2)
+	// Present and duplicated values do not count.
+	s.Add(1, 2, 3, 3)
+	expectFull(t, "Add", func() { s.Add(4) })
+	s.Delete(1)
+	expectFull(t, "Add", func() { s.Add(4, 5) })
+	if !s.Has(4) || s.Has(5) || s.Size() != 3 {
+		t.Error("expected a failed Add to keep the values added before the full one")
+	}
+	s.Add(2, 3, 4)
+}
+
+func TestWithMaxSizeComparerSet(t *testing.T) {
+	s := WithMaxSize(NewComparerSet(), 2).(Set)
+	// Values comparing to zero count once, though they are not ==.
+	s.Add(caseInsensitive("a"), caseInsensitive("A"), caseInsensitive("b"))
+	if s.Size() != 2 {
+		t.Errorf("expected 2 elements, got %d", s.Size())
+	}
+	expectFull(t, "Add", func() { s.Add(caseInsensitive("B"), caseInsensitive("c")) })
+}
+
+func TestWithMaxSizeNotComparable(t *testing.T) {
+	s := WithMaxSize(NewComparerSet(), 1).(Set)
+	s.Add(sliceComparer{1}, sliceComparer{1})
+	if s.Size() != 1 {
+		t.Errorf("expected 1 element, got %d", s.Size())
+	}
+}
+
+// sliceComparer is a Comparer which is not comparable with ==.
+type sliceComparer []int
+
+func (c sliceComparer) Compare(other Comparer) int {
+	return c[0] - other.(sliceComparer)[0]
+}
+
+func TestWithMaxSizeMap(t *testing.T) {
+	m := WithMaxSize(newTestMap(), 2).(Map)
+	m.Add("a", 1).Add("b", 2)
+	m.Add("a", 3)
+	expectFull(t, "Add", func() { m.Add("c", 3) })
+	expectFull(t, "ComputeIfAbsent", func() {
+		m.ComputeIfAbsent("c", func() interface{} { return 3 })
+	})
+	if v, _ := m.Get("a"); v != 3 || m.Size() != 2 {
+		t.Errorf("expected a to be overwritten, got %v", v)
+	}
+}
+
+func TestWithMaxSizeMapClone(t *testing.T) {
+	m := WithMaxSize(NewShardedMap(), 1).(Map)
+	m.Add("a", 1)
+	clone := m.Clone()
+	expectFull(t, "Add to the clone", func() { clone.Add("b", 2) })
+	clone.Delete("a")
+	clone.Add("b", 2)
+	if !m.Has("a") || m.Has("b") {
+		t.Error("expected the original to be unchanged")
+	}
+}
+
+func TestWithMaxSizeUnsupported(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected WithMaxSize to panic on an unsupported Container")
+		}
+	}()
+	WithMaxSize(NewOrderStatisticTree(intCompare), 1)
+}
@@ -0,0 +1,145 @@
+package gods
+
+import (
+	"fmt"
+	"sort"
+)
+
+// MergeMaps returns a KVIterator yielding the (key,value) pairs of a and b
+// in ascending key order according to cmp. A key present in both Maps is
+// yielded once, bound to the result of onBoth called with its value in a
+// and in b.
+//
+// The merge streams the pairs of the Maps implementing SortedKVIterable,
+// such as ComparerMap, holding one pair of each at a time, and panics if
+// they do not come in ascending order according to cmp. The pairs of the
+// other Maps are gathered with RangeKV and sorted upfront, which takes
+// O(n) memory. The calls to onBoth happen lazily as the KVIterator is
+// consumed. Panics if a Map implements neither SortedKVIterable nor
+// KVRanger.
+func MergeMaps(a, b Map, cmp func(k1, k2 interface{}) int, onBoth func(k, va, vb interface{}) interface{}) KVIterator {
+	return &mergeIterator{
+		a:      newMergeInput(a, cmp),
+		b:      newMergeInput(b, cmp),
+		cmp:    cmp,
+		onBoth: onBoth,
+	}
+}
+
+type kvPair struct {
+	key, value interface{}
+}
+
+// mergeInput looks ahead one pair of a Map being merged.
+type mergeInput struct {
+	it   KVIterator
+	cmp  func(k1, k2 interface{}) int
+	pair kvPair
+	ok   bool
+}
+
+func newMergeInput(m Map, cmp func(k1, k2 interface{}) int) *mergeInput {
+	var it KVIterator
+	if sorted, ok := m.(SortedKVIterable); ok {
+		it = sorted.SortedKVIterator()
+	} else {
+		it = &pairsIterator{pairs: sortedPairs(m, cmp), i: -1}
+	}
+	in := &mergeInput{it: it, cmp: cmp}
+	in.advance()
+	return in
+}
+
+// advance looks ahead the next pair, checking that keys ascend.
+func (in *mergeInput) advance() {
+	previous, hadPrevious := in.pair.key, in.ok
+	if in.ok = in.it.Next(); !in.ok {
+		return
+	}
+	in.pair = kvPair{in.it.Key(), in.it.Value()}
+	if hadPrevious && mustCompare(previous, in.pair.key, in.cmp) >= 0 {
+		panic(fmt.Sprintf("gods: can not merge keys not in ascending order, %v then %v", previous, in.pair.key))
+	}
+}
+
+func sortedPairs(m Map, cmp func(k1, k2 interface{}) int) []kvPair {
+	r, ok := m.(KVRanger)
+	if !ok {
+		panic(fmt.Sprintf("gods: can not range over %T", m))
+	}
+	pairs := make([]kvPair, 0, m.Size())
+	r.RangeKV(func(key, value interface{}) bool {
+		pairs = append(pairs, kvPair{key, value})
+		return true
+	})
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return mustCompare(pairs[i].key, pairs[j].key, cmp) < 0
+	})
+	return pairs
+}
+
+// pairsIterator is a KVIterator over pairs.
+type pairsIterator struct {
+	pairs []kvPair
+	i     int
+}
+
+func (it *pairsIterator) Next() bool {
+	if it.i+1 >= len(it.pairs) {
+		return false
+	}
+	it.i++
+	return true
+}
+
+func (it *pairsIterator) Key() interface{} {
+	return it.pairs[it.i].key
+}
+
+func (it *pairsIterator) Value() interface{} {
+	return it.pairs[it.i].value
+}
+
+type mergeIterator struct {
+	a, b    *mergeInput
+	cmp     func(k1, k2 interface{}) int
+	onBoth  func(k, va, vb interface{}) interface{}
+	current kvPair
+}
+
+func (it *mergeIterator) Next() bool {
+	a, b := it.a, it.b
+	switch {
+	case !a.ok && !b.ok:
+		return false
+	case !b.ok:
+		it.current = a.pair
+		a.advance()
+	case !a.ok:
+		it.current = b.pair
+		b.advance()
+	default:
+		switch c := mustCompare(a.pair.key, b.pair.key, it.cmp); {
+		case c < 0:
+			it.current = a.pair
+			a.advance()
+		case c > 0:
+			it.current = b.pair
+			b.advance()
+		default:
+			key := a.pair.key
+			it.current = kvPair{key, it.onBoth(key, a.pair.value, b.pair.value)}
+			a.advance()
+			b.advance()
+		}
+	}
+	return true
+}
+
+func (it *mergeIterator) Key() interface{} {
+	return it.current.key
+}
+
+func (it *mergeIterator) Value() interface{} {
+	return it.current.value
+}
@@ -0,0 +1,113 @@
+package gods
+
+import (
+	"fmt"
+	"testing"
+)
+
+// collectMerge returns the "key:value" strings yielded by it.
+func collectMerge(it KVIterator) string {
+	var merged []string
+	for it.Next() {
+		merged = append(merged, fmt.Sprint(it.Key(), ":", it.Value()))
+	}
+	return fmt.Sprint(merged)
+}
+
+func TestMergeMaps(t *testing.T) {
+	a := NewComparerMap()
+	a.Add(Int(1), "a1").Add(Int(3), "a3").Add(Int(5), "a5").Add(Int(6), "a6")
+	b := NewComparerMap()
+	b.Add(Int(2), "b2").Add(Int(3), "b3").Add(Int(6), "b6").Add(Int(9), "b9")
+
+	calls := 0
+	it := MergeMaps(a, b, compareComparers, func(k, va, vb interface{}) interface{} {
+		calls++
+		return fmt.Sprint(va, "+", vb)
+	})
+	if calls != 0 {
+		t.Errorf("expected onBoth to be called lazily, got %d calls", calls)
+	}
+	want := "[1:a1 2:b2 3:a3+b3 5:a5 6:a6+b6 9:b9]"
+	if got := collectMerge(it); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+	if calls != 2 || it.Next() {
+		t.Errorf("expected exhausted iterator after 2 conflicts, got %d", calls)
+	}
+
+	disjoint := NewComparerMap()
+	disjoint.Add(Int(0), "c0").Add(Int(10), "c10")
+	want = "[0:c0 1:a1 3:a3 5:a5 6:a6 10:c10]"
+	if got := collectMerge(MergeMaps(a, disjoint, compareComparers, nil)); got != want {
+		t.Errorf("expected %s merging disjoint keys, got %s", want, got)
+	}
+
+	empty := MergeMaps(NewComparerMap(), NewComparerMap(), compareComparers, nil)
+	if empty.Next() {
+		t.Error("expected merging empty maps to yield nothing")
+	}
+}
+
+// countingSortedMap counts the pairs pulled from its SortedKVIterator.
+type countingSortedMap struct {
+	*ComparerMap
+	pulled int
+}
+
+func (m *countingSortedMap) SortedKVIterator() KVIterator {
+	return &countingKVIterator{m.ComparerMap.SortedKVIterator(), &m.pulled}
+}
+
+type countingKVIterator struct {
+	KVIterator
+	pulled *int
+}
+
+func (it *countingKVIterator) Next() bool {
+	*it.pulled++
+	return it.KVIterator.Next()
+}
+
+func TestMergeMapsStreams(t *testing.T) {
+	a, b := &countingSortedMap{ComparerMap: NewComparerMap()}, &countingSortedMap{ComparerMap: NewComparerMap()}
+	for i := 0; i < 100; i++ {
+		a.Add(Int(2*i), i)
+		b.Add(Int(2*i+1), i)
+	}
+	it := MergeMaps(a, b, compareComparers, nil)
+	for i := 0; i < 3; i++ {
+		it.Next()
+	}
+	// Each input looks one pair ahead.
+	if a.pulled != 3 || b.pulled != 2 {
+		t.Errorf("expected 3 and 2 pairs pulled after 3 merged, got %d and %d", a.pulled, b.pulled)
+	}
+}
+
+func TestMergeMapsUnsorted(t *testing.T) {
+	// ShardedMap does not range in key order, so its pairs are sorted
+	// upfront, and it can be merged with a ComparerMap.
+	a := NewShardedMap()
+	for _, k := range []Int{7, 1, 4} {
+		a.Add(k, fmt.Sprint("a", k))
+	}
+	b := NewComparerMap()
+	b.Add(Int(4), "b4").Add(Int(2), "b2")
+	want := "[1:a1 2:b2 4:a4+b4 7:a7]"
+	got := collectMerge(MergeMaps(a, b, compareComparers, func(k, va, vb interface{}) interface{} {
+		return fmt.Sprint(va, "+", vb)
+	}))
+	if got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestMergeMapsOrderMismatch(t *testing.T) {
+	a := NewComparerMap()
+	a.Add(Int(1), 1).Add(Int(2), 2)
+	descending := func(x, y interface{}) int { return -compareComparers(x, y) }
+	if !panics(func() { collectMerge(MergeMaps(a, NewComparerMap(), descending, nil)) }) {
+		t.Error("expected merging with a cmp disagreeing with the Map order to panic")
+	}
+}
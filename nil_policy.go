@@ -0,0 +1,51 @@
+package gods
+
+// NilPolicy controls how a Set or Map handles nil keys and nil values on
+// insertion. Policies for keys and values can be combined with |, e.g.
+// RejectNilKey|IgnoreNilValue. Only untyped nils are considered.
+type NilPolicy uint8
+
+const (
+	// AllowNil stores nil keys and values like any other. It is the
+	// default policy.
+	AllowNil NilPolicy = 0
+	// RejectNilKey panics when inserting a nil key.
+	RejectNilKey NilPolicy = 1 << 0
+	// IgnoreNilKey silently skips inserting a nil key.
+	IgnoreNilKey NilPolicy = 1 << 1
+	// RejectNilValue panics when inserting a nil value.
+	RejectNilValue NilPolicy = 1 << 2
+	// IgnoreNilValue silently skips inserting a nil value, leaving the key
+	// unbound or bound to its previous value.
+	IgnoreNilValue NilPolicy = 1 << 3
+)
+
+// admitKey reports whether key may be inserted, panicking if it is
+// rejected.
+func (p NilPolicy) admitKey(key interface{}) bool {
+	if key != nil {
+		return true
+	}
+	if p&RejectNilKey != 0 {
+		panic("gods: nil key rejected by NilPolicy")
+	}
+	return p&IgnoreNilKey == 0
+}
+
+// admitValue reports whether value may be inserted, panicking if it is
+// rejected.
+func (p NilPolicy) admitValue(value interface{}) bool {
+	if value != nil {
+		return true
+	}
+	if p&RejectNilValue != 0 {
+		panic("gods: nil value rejected by NilPolicy")
+	}
+	return p&IgnoreNilValue == 0
+}
+
+// admit reports whether the (key,value) pair may be inserted, panicking if
+// it is rejected.
+func (p NilPolicy) admit(key, value interface{}) bool {
+	return p.admitKey(key) && p.admitValue(value)
+}
@@ -0,0 +1,113 @@
+package gods
+
+import "testing"
+
+func TestNilPolicyShardedMap(t *testing.T) {
+	tests := []struct {
+		policy               NilPolicy
+		keyPanics, keyStored bool
+		valPanics, valStored bool
+	}{
+		{AllowNil, false, true, false, true},
+		{RejectNilKey, true, false, false, true},
+		{IgnoreNilKey, false, false, false, true},
+		{RejectNilValue, false, true, true, false},
+		{IgnoreNilValue, false, true, false, false},
+		{RejectNilKey | IgnoreNilValue, true, false, false, false},
+	}
+	for _, tt := range tests {
+		m := NewShardedMapWithNilPolicy(4, tt.policy)
+		if got := panics(func() { m.Add(nil, 1) }); got != tt.keyPanics {
+			t.Errorf("policy %d: expected nil key panic %v, got %v", tt.policy, tt.keyPanics, got)
+		}
+		if got := m.Has(nil); got != tt.keyStored {
+			t.Errorf("policy %d: expected nil key stored %v, got %v", tt.policy, tt.keyStored, got)
+		}
+		if got := panics(func() { m.Add("k", nil) }); got != tt.valPanics {
+			t.Errorf("policy %d: expected nil value panic %v, got %v", tt.policy, tt.valPanics, got)
+		}
+		if got := m.Has("k"); got != tt.valStored {
+			t.Errorf("policy %d: expected nil value stored %v, got %v", tt.policy, tt.valStored, got)
+		}
+		if derived := m.FilterKeys(func(interface{}) bool { return true }); derived.(*ShardedMap).nilPolicy != tt.policy {
+			t.Errorf("policy %d: expected derived maps to keep the policy", tt.policy)
+		}
+	}
+}
+
+func TestNilPolicyIgnoreNilValue(t *testing.T) {
+	m := NewShardedMapWithNilPolicy(1, IgnoreNilValue)
+	m.Add("k", 1).Add("k", nil)
+	m.ComputeIfPresent("k", func(interface{}) interface{} { return nil })
+	if v, _ := m.Get("k"); v != 1 {
+		t.Errorf("expected nil values to leave k bound to 1, got %v", v)
+	}
+	m.ComputeIfAbsent("absent", func() interface{} { return nil })
+	if m.Has("absent") {
+		t.Error("expected a nil supplied value not to be stored")
+	}
+	if m.MapValues(func(interface{}) interface{} { return nil }).Size() != 0 {
+		t.Error("expected nil projected values to be ignored")
+	}
+}
+
+func TestNilPolicyComparer(t *testing.T) {
+	if !panics(func() { NewComparerMap().Add(nil, 1) }) {
+		t.Error("expected a nil key to panic by default")
+	}
+	m := NewComparerMapWithNilPolicy(IgnoreNilKey | RejectNilValue)
+	m.Add(nil, 1)
+	if !m.Empty() {
+		t.Error("expected a nil key to be ignored")
+	}
+	if !panics(func() { m.Add(caseInsensitive("k"), nil) }) {
+		t.Error("expected a nil value to panic")
+	}
+	if m.Add(caseInsensitive("k"), 1).Size() != 1 {
+		t.Error("expected a non nil pair to be added")
+	}
+
+	if !panics(func() { NewComparerSet(nil) }) {
+		t.Error("expected a nil element to panic by default")
+	}
+	s := NewComparerSetWithNilPolicy(IgnoreNilKey, caseInsensitive("a"), nil)
+	if s.Size() != 1 {
+		t.Errorf("expected the nil element to be ignored, got size %d", s.Size())
+	}
+}
+
+func TestNilPolicyComparerLookups(t *testing.T) {
+	m := NewComparerMapWithNilPolicy(IgnoreNilKey)
+	m.Add(caseInsensitive("k"), 1)
+	if v, ok := m.Get(nil); ok || v != nil || m.Has(nil) {
+		t.Errorf("expected an ignored nil key to be absent, got (%v, %v)", v, ok)
+	}
+	m.Delete(nil)
+	if n := m.DeleteAll(nil, caseInsensitive("K")); n != 1 {
+		t.Errorf("expected only k to be deleted, got %d", n)
+	}
+	if v := m.ComputeIfAbsent(nil, func() interface{} { return 2 }); v != 2 || !m.Empty() {
+		t.Errorf("expected the supplied value returned without binding a nil key, got %v", v)
+	}
+	m.ComputeIfPresent(nil, func(interface{}) interface{} { return 3 })
+	if !m.Empty() {
+		t.Error("expected ComputeIfPresent to ignore a nil key")
+	}
+
+	rejecting := NewComparerMapWithNilPolicy(RejectNilKey)
+	if !panics(func() { rejecting.Get(nil) }) || !panics(func() { rejecting.Delete(nil) }) {
+		t.Error("expected a rejected nil key to panic on lookups too")
+	}
+
+	s := NewComparerSetWithNilPolicy(IgnoreNilKey, caseInsensitive("a"))
+	if s.Has(nil) {
+		t.Error("expected an ignored nil element to be absent")
+	}
+	if v, ok := s.Find(nil); ok || v != nil {
+		t.Errorf("expected no nil element found, got (%v, %v)", v, ok)
+	}
+	s.Delete(nil)
+	if n := s.RemoveAll(nil, caseInsensitive("A")); n != 1 || !s.Empty() {
+		t.Errorf("expected only a to be removed, got %d", n)
+	}
+}
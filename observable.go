@@ -0,0 +1,171 @@
+package gods
+
+import "fmt"
+
+// MutationKind is the kind of change a MutationEvent reports.
+type MutationKind int
+
+const (
+	// MutationAdd reports an element added to a Container.
+	MutationAdd MutationKind = iota
+	// MutationRemove reports an element removed from a Container.
+	MutationRemove
+	// MutationClear reports a Container cleared.
+	MutationClear
+)
+
+func (k MutationKind) String() string {
+	switch k {
+	case MutationAdd:
+		return "Add"
+	case MutationRemove:
+		return "Remove"
+	case MutationClear:
+		return "Clear"
+	}
+	return fmt.Sprintf("MutationKind(%d)", int(k))
+}
+
+// MutationEvent describes a change applied to an ObservableContainer.
+type MutationEvent struct {
+	Kind MutationKind
+	// Element is the added or removed element, the key for a Map, or nil
+	// for MutationClear.
+	Element interface{}
+	// Size is the size of the Container after the change.
+	Size int
+}
+
+// ObservableContainer wraps a Container to emit a MutationEvent to its
+// subscribers after each change made through it. Changes made to the
+// wrapped Container directly are not observed. An ObservableContainer is not
+// safe for concurrent use.
+type ObservableContainer struct {
+	Container
+	subscribers []subscriber
+	nextID      int
+}
+
+type subscriber struct {
+	id int
+	fn func(MutationEvent)
+}
+
+// Observe wraps c into an ObservableContainer without subscribers.
+func Observe(c Container) *ObservableContainer {
+	return &ObservableContainer{Container: c}
+}
+
+// Subscribe registers fn to be called with each MutationEvent, after the
+// previously registered functions, and returns an ID to Unsubscribe it.
+func (o *ObservableContainer) Subscribe(fn func(event MutationEvent)) int {
+	o.nextID++
+	o.subscribers = append(o.subscribers, subscriber{o.nextID, fn})
+	return o.nextID
+}
+
+// Unsubscribe stops calling the function registered under id, and reports
+// whether there was one.
+func (o *ObservableContainer) Unsubscribe(id int) bool {
+	for i, s := range o.subscribers {
+		if s.id == id {
+			o.subscribers = append(o.subscribers[:i:i], o.subscribers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (o *ObservableContainer) emit(kind MutationKind, element interface{}) {
+	event := MutationEvent{Kind: kind, Element: element, Size: o.Size()}
+	for _, s := range o.subscribers {
+		s.fn(event)
+	}
+}
+
+// emitIfResized emits a MutationEvent if the size changed from before,
+// which tells whether the operation actually changed the Container.
+func (o *ObservableContainer) emitIfResized(before int, kind MutationKind, element interface{}) {
+	if o.Size() != before {
+		o.emit(kind, element)
+	}
+}
+
+// Clear resets the wrapped Container, and emits a single MutationClear.
+func (o *ObservableContainer) Clear() {
+	o.Container.Clear()
+	o.emit(MutationClear, nil)
+}
+
+// Push pushes v to the wrapped Container, which must have a
+// Push(interface{}) method like Stack, Queue and PriorityQueue, and emits a
+// MutationAdd if it was added.
+func (o *ObservableContainer) Push(v interface{}) {
+	c, ok := o.Container.(interface{ Push(interface{}) })
+	if !ok {
+		panic(fmt.Sprintf("gods: %T has no Push method", o.Container))
+	}
+	before := o.Size()
+	c.Push(v)
+	o.emitIfResized(before, MutationAdd, v)
+}
+
+// Pop pops an element from the wrapped Container, which must have a
+// Pop() interface{} method like Stack, Queue and PriorityQueue, and emits a
+// MutationRemove if one was removed.
+func (o *ObservableContainer) Pop() interface{} {
+	c, ok := o.Container.(interface{ Pop() interface{} })
+	if !ok {
+		panic(fmt.Sprintf("gods: %T has no Pop method", o.Container))
+	}
+	before := o.Size()
+	v := c.Pop()
+	o.emitIfResized(before, MutationRemove, v)
+	return v
+}
+
+// Add adds the values one by one to the wrapped Container, which must be a
+// Set, and emits a MutationAdd for each value which was not present.
+func (o *ObservableContainer) Add(values ...interface{}) {
+	s, ok := o.Container.(Set)
+	if !ok {
+		panic(fmt.Sprintf("gods: %T is not a Set", o.Container))
+	}
+	for _, v := range values {
+		before := o.Size()
+		s.Add(v)
+		o.emitIfResized(before, MutationAdd, v)
+	}
+}
+
+// Put binds key to value in the wrapped Container, which must be a Map, and
+// emits a MutationAdd with key if it was not present.
+func (o *ObservableContainer) Put(key, value interface{}) {
+	m, ok := o.Container.(Map)
+	if !ok {
+		panic(fmt.Sprintf("gods: %T is not a Map", o.Container))
+	}
+	before := o.Size()
+	m.Add(key, value)
+	o.emitIfResized(before, MutationAdd, key)
+}
+
+// Delete deletes the values, or keys, one by one from the wrapped
+// Container, which must be a Set or a Map, and emits a MutationRemove for
+// each one which was present.
+func (o *ObservableContainer) Delete(values ...interface{}) {
+	var del func(interface{})
+	switch c := o.Container.(type) {
+	case Set:
+		del = func(v interface{}) { c.Delete(v) }
+	case Map:
+		del = c.Delete
+	default:
+		panic(fmt.Sprintf("gods: %T is neither a Set nor a Map", o.Container))
+	}
+	for _, v := range values {
+		before := o.Size()
+		del(v)
+		o.emitIfResized(before, MutationRemove, v)
+	}
+}
@@ -0,0 +1,66 @@
+package gods
+
+import (
+	"fmt"
+	"testing"
+)
+
+// eventRecorder records the MutationEvents it is subscribed to.
+type eventRecorder []string
+
+func (r *eventRecorder) record(event MutationEvent) {
+	*r = append(*r, fmt.Sprintf("%v %v %d", event.Kind, event.Element, event.Size))
+}
+
+func TestObservableQueue(t *testing.T) {
+	o := Observe(NewDedupQueue())
+	var first, second eventRecorder
+	o.Subscribe(first.record)
+	id := o.Subscribe(second.record)
+
+	o.Push("a")
+	o.Push("b")
+	o.Push("a") // deduplicated, so not added
+	o.Pop()
+	if !o.Unsubscribe(id) || o.Unsubscribe(id) {
+		t.Error("expected the second subscriber to be unsubscribed once")
+	}
+	o.Pop()
+	o.Pop() // empty, so nothing removed
+	o.Push("c")
+	o.Clear()
+
+	want := "[Add a 1 Add b 2 Remove a 1 Remove b 0 Add c 1 Clear <nil> 0]"
+	if got := fmt.Sprint(first); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+	if got := fmt.Sprint(second); got != "[Add a 1 Add b 2 Remove a 1]" {
+		t.Errorf("expected events until Unsubscribe, got %s", got)
+	}
+}
+
+func TestObservableSetAndMap(t *testing.T) {
+	set := Observe(newTestSet())
+	var events eventRecorder
+	set.Subscribe(events.record)
+	set.Add(1, 2, 1)
+	set.Delete(1, 3)
+	set.Clear()
+	if got := fmt.Sprint(events); got != "[Add 1 1 Add 2 2 Remove 1 1 Clear <nil> 0]" {
+		t.Errorf("unexpected Set events %s", got)
+	}
+
+	m := Observe(NewShardedMap())
+	events = nil
+	m.Subscribe(events.record)
+	m.Put("k", 1)
+	m.Put("k", 2) // rebound, so not added
+	m.Delete("k", "missing")
+	if got := fmt.Sprint(events); got != "[Add k 1 Remove k 0]" {
+		t.Errorf("unexpected Map events %s", got)
+	}
+
+	if !panics(func() { m.Push(1) }) || !panics(func() { set.Put(1, 1) }) {
+		t.Error("expected unsupported mutations to panic")
+	}
+}
@@ -0,0 +1,431 @@
+package gods
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+)
+
+// OrderStatisticTree is an ordered multiset Container backed by an AVL tree
+// whose nodes are augmented with the size of their subtree. Besides
+// O(log n) Insert, Delete and Has, it answers order statistic queries in
+// O(log n): the k-th smallest element with Select, and the number of
+// smaller elements with Rank.
+type OrderStatisticTree struct {
+	cmp  func(a, b interface{}) int
+	root *ostNode
+}
+
+type ostNode struct {
+	value       interface{}
+	left, right *ostNode
+	height      int
+	size        int
+}
+
+var (
+	_ KeyRanger = (*OrderStatisticTree)(nil)
+	_ Tree      = (*OrderStatisticTree)(nil)
+)
+
+// NewOrderStatisticTree creates an empty OrderStatisticTree ordered by cmp,
+// which returns a negative number if a < b, zero if a == b, and a positive
+// number if a > b.
+func NewOrderStatisticTree(cmp func(a, b interface{}) int) *OrderStatisticTree {
+	return &OrderStatisticTree{cmp: cmp}
+}
+
+// Empty indicates if the OrderStatisticTree is empty.
+func (t *OrderStatisticTree) Empty() bool {
+	return t.Size() == 0
+}
+
+// Size retrieves the number of elements in the OrderStatisticTree.
+func (t *OrderStatisticTree) Size() int {
+	return t.root.getSize()
+}
+
+// Clear resets OrderStatisticTree, it will be empty with size 0.
+func (t *OrderStatisticTree) Clear() {
+	t.root = nil
+}
+
+// Height retrieves the length of the longest downward path from the root
+// to a leaf, or -1 if the OrderStatisticTree is empty.
+func (t *OrderStatisticTree) Height() int {
+	return t.root.getHeight() - 1
+}
+
+// MinHeight retrieves the length of the shortest downward path from the
+// root to a leaf, or -1 if the OrderStatisticTree is empty.
+func (t *OrderStatisticTree) MinHeight() int {
+	return t.root.minHeight() - 1
+}
+
+// Diameter retrieves the length of the longest path between any two nodes
+// of the OrderStatisticTree, which does not necessarily pass through the
+// root, or -1 if it is empty.
+func (t *OrderStatisticTree) Diameter() int {
+	_, diameter, _ := t.root.shape()
+	return diameter - 1
+}
+
+// IsBalanced indicates if the heights of the subtrees of every node differ
+// by at most one. The OrderStatisticTree keeps itself balanced, so it is
+// false only if the invariant is broken.
+func (t *OrderStatisticTree) IsBalanced() bool {
+	_, _, balanced := t.root.shape()
+	return balanced
+}
+
+// Insert adds an element to the OrderStatisticTree. Equal elements are
+// kept side by side.
+func (t *OrderStatisticTree) Insert(v interface{}) {
+	t.root = t.insert(t.root, v)
+}
+
+// Delete removes one element equal to v from the OrderStatisticTree, and
+// reports whether there was one.
+func (t *OrderStatisticTree) Delete(v interface{}) bool {
+	var deleted bool
+	t.root, deleted = t.delete(t.root, v)
+	return deleted
+}
+
+// Has checks whether an element equal to v is in the OrderStatisticTree.
+func (t *OrderStatisticTree) Has(v interface{}) bool {
+	_, ok := t.Find(v)
+	return ok
+}
+
+// Find returns the element equal to v in the OrderStatisticTree, which may
+// differ from v if cmp only compares part of the elements.
+// Returns (nil, false) if there is none.
+func (t *OrderStatisticTree) Find(v interface{}) (interface{}, bool) {
+	for n := t.root; n != nil; {
+		switch c := mustCompare(v, n.value, t.cmp); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n.value, true
+		}
+	}
+	return nil, false
+}
+
+// Search returns an ArraySlice of the elements from the root down to the
+// node of the element equal to key, both included. Returns an empty
+// ArraySlice and false if there is none.
+func (t *OrderStatisticTree) Search(key interface{}) (path Slice, found bool) {
+	var raw []interface{}
+	for n := t.root; n != nil; {
+		raw = append(raw, n.value)
+		switch c := mustCompare(key, n.value, t.cmp); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return &ArraySlice{raw: raw}, true
+		}
+	}
+	return NewArraySlice(), false
+}
+
+// Select returns the k-th smallest element, counting from 0. Returns nil if
+// k is out of the [0, Size) range.
+func (t *OrderStatisticTree) Select(k int) interface{} {
+	if k < 0 || k >= t.Size() {
+		return nil
+	}
+	n := t.root
+	for {
+		switch leftSize := n.left.getSize(); {
+		case k < leftSize:
+			n = n.left
+		case k > leftSize:
+			k -= leftSize + 1
+			n = n.right
+		default:
+			return n.value
+		}
+	}
+}
+
+// Rank returns the number of elements strictly lower than v, which is the
+// index Select returns the first element equal to v at, if any.
+func (t *OrderStatisticTree) Rank(v interface{}) int {
+	rank := 0
+	for n := t.root; n != nil; {
+		if mustCompare(v, n.value, t.cmp) <= 0 {
+			n = n.left
+		} else {
+			rank += n.left.getSize() + 1
+			n = n.right
+		}
+	}
+	return rank
+}
+
+// iterator returns an Iterator over the elements in ascending order, which
+// holds a path of O(log n) nodes.
+func (t *OrderStatisticTree) iterator() *ostIterator {
+	it := &ostIterator{}
+	it.pushLeft(t.root)
+	return it
+}
+
+// ostIterator walks a subtree in order with the stack of the nodes whose
+// left subtree is being walked.
+type ostIterator struct {
+	stack   []*ostNode
+	current *ostNode
+}
+
+func (it *ostIterator) pushLeft(n *ostNode) {
+	for ; n != nil; n = n.left {
+		it.stack = append(it.stack, n)
+	}
+}
+
+func (it *ostIterator) Next() bool {
+	if len(it.stack) == 0 {
+		it.current = nil
+		return false
+	}
+	it.current = it.stack[len(it.stack)-1]
+	it.stack = it.stack[:len(it.stack)-1]
+	it.pushLeft(it.current.right)
+	return true
+}
+
+func (it *ostIterator) Value() interface{} {
+	return it.current.value
+}
+
+// RangeWithKey iterates OrderStatisticTree elements in ascending order.
+// Stop iterating if the KeyRangerFunc returns false.
+func (t *OrderStatisticTree) RangeWithKey(fn KeyRangerFunc) {
+	var stack []*ostNode
+	for n := t.root; n != nil || len(stack) > 0; n = n.right {
+		for ; n != nil; n = n.left {
+			stack = append(stack, n)
+		}
+		n = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !fn(n.value) {
+			return
+		}
+	}
+}
+
+// Serialize encodes the elements of OrderStatisticTree in ascending order
+// with encoding/gob, so that Deserialize can rebuild it. Elements of types
+// other than the predeclared ones must be registered with gob.Register.
+func (t *OrderStatisticTree) Serialize() ([]byte, error) {
+	values := make([]interface{}, 0, t.Size())
+	t.RangeWithKey(func(v interface{}) bool {
+		values = append(values, v)
+		return true
+	})
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(values); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Deserialize replaces the elements of OrderStatisticTree with the ones
+// encoded by Serialize, building a perfectly balanced tree in O(n). The
+// OrderStatisticTree is left unchanged if data can not be decoded, or if
+// its elements are not in ascending order according to cmp.
+func (t *OrderStatisticTree) Deserialize(data []byte) error {
+	var values []interface{}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&values); err != nil {
+		return err
+	}
+	for i := 1; i < len(values); i++ {
+		c, err := safeCompare(values[i-1], values[i], t.cmp)
+		if err != nil {
+			return err
+		}
+		if c > 0 {
+			return errors.New("gods: serialized elements are not in ascending order")
+		}
+	}
+	t.root = buildOST(values)
+	return nil
+}
+
+// clone copies the subtree of n, replacing each value with copyValue(value),
+// and keeps its shape.
+func (n *ostNode) clone(copyValue func(interface{}) interface{}) *ostNode {
+	if n == nil {
+		return nil
+	}
+	return &ostNode{
+		value:  copyValue(n.value),
+		left:   n.left.clone(copyValue),
+		right:  n.right.clone(copyValue),
+		height: n.height,
+		size:   n.size,
+	}
+}
+
+// buildOST builds a balanced subtree from values in ascending order.
+func buildOST(values []interface{}) *ostNode {
+	if len(values) == 0 {
+		return nil
+	}
+	mid := len(values) / 2
+	n := &ostNode{value: values[mid], left: buildOST(values[:mid]), right: buildOST(values[mid+1:])}
+	n.update()
+	return n
+}
+
+func (t *OrderStatisticTree) insert(n *ostNode, v interface{}) *ostNode {
+	if n == nil {
+		return &ostNode{value: v, height: 1, size: 1}
+	}
+	if mustCompare(v, n.value, t.cmp) < 0 {
+		n.left = t.insert(n.left, v)
+	} else {
+		n.right = t.insert(n.right, v)
+	}
+	return n.rebalance()
+}
+
+func (t *OrderStatisticTree) delete(n *ostNode, v interface{}) (*ostNode, bool) {
+	if n == nil {
+		return nil, false
+	}
+	var deleted bool
+	switch c := mustCompare(v, n.value, t.cmp); {
+	case c < 0:
+		n.left, deleted = t.delete(n.left, v)
+	case c > 0:
+		n.right, deleted = t.delete(n.right, v)
+	default:
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+		var successor *ostNode
+		n.right, successor = n.right.deleteMin()
+		successor.left, successor.right = n.left, n.right
+		return successor.rebalance(), true
+	}
+	return n.rebalance(), deleted
+}
+
+// deleteMin removes the minimum node of the subtree n, and returns the new
+// subtree along with the removed node.
+func (n *ostNode) deleteMin() (*ostNode, *ostNode) {
+	if n.left == nil {
+		return n.right, n
+	}
+	var min *ostNode
+	n.left, min = n.left.deleteMin()
+	return n.rebalance(), min
+}
+
+// minHeight returns the number of nodes on the shortest downward path from
+// n to a leaf.
+func (n *ostNode) minHeight() int {
+	switch {
+	case n == nil:
+		return 0
+	case n.left == nil:
+		return n.right.minHeight() + 1
+	case n.right == nil:
+		return n.left.minHeight() + 1
+	}
+	l, r := n.left.minHeight(), n.right.minHeight()
+	if l < r {
+		return l + 1
+	}
+	return r + 1
+}
+
+// shape returns the height of the subtree n and the number of nodes on its
+// longest path, walking it rather than trusting the heights stored in the
+// nodes, and whether it is balanced.
+func (n *ostNode) shape() (height, diameter int, balanced bool) {
+	if n == nil {
+		return 0, 0, true
+	}
+	lh, ld, lb := n.left.shape()
+	rh, rd, rb := n.right.shape()
+	height, diameter = lh+1, lh+rh+1
+	if rh > lh {
+		height = rh + 1
+	}
+	if ld > diameter {
+		diameter = ld
+	}
+	if rd > diameter {
+		diameter = rd
+	}
+	return height, diameter, lb && rb && lh-rh <= 1 && rh-lh <= 1
+}
+
+func (n *ostNode) getSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *ostNode) getHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *ostNode) update() {
+	n.size = n.left.getSize() + n.right.getSize() + 1
+	n.height = n.left.getHeight() + 1
+	if h := n.right.getHeight() + 1; h > n.height {
+		n.height = h
+	}
+}
+
+// rebalance restores the AVL invariant of n, whose subtrees are balanced
+// and differ in height by at most two, and returns the new subtree root.
+func (n *ostNode) rebalance() *ostNode {
+	n.update()
+	switch balance := n.left.getHeight() - n.right.getHeight(); {
+	case balance > 1:
+		if n.left.left.getHeight() < n.left.right.getHeight() {
+			n.left = n.left.rotateLeft()
+		}
+		return n.rotateRight()
+	case balance < -1:
+		if n.right.right.getHeight() < n.right.left.getHeight() {
+			n.right = n.right.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+	return n
+}
+
+func (n *ostNode) rotateLeft() *ostNode {
+	r := n.right
+	n.right, r.left = r.left, n
+	n.update()
+	r.update()
+	return r
+}
+
+func (n *ostNode) rotateRight() *ostNode {
+	l := n.left
+	n.left, l.right = l.right, n
+	n.update()
+	l.update()
+	return l
+}
@@ -0,0 +1,226 @@
+package gods
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// checkOST verifies the AVL and size invariants of the subtree n, and
+// returns its height.
+func checkOST(t *testing.T, n *ostNode) int {
+	t.Helper()
+	if n == nil {
+		return 0
+	}
+	l, r := checkOST(t, n.left), checkOST(t, n.right)
+	if l-r > 1 || r-l > 1 {
+		t.Fatalf("unbalanced node %v: heights %d and %d", n.value, l, r)
+	}
+	if n.size != n.left.getSize()+n.right.getSize()+1 {
+		t.Fatalf("wrong size %d at node %v", n.size, n.value)
+	}
+	h := l + 1
+	if r >= l {
+		h = r + 1
+	}
+	if n.height != h {
+		t.Fatalf("wrong height %d at node %v, expected %d", n.height, n.value, h)
+	}
+	return h
+}
+
+func checkOSTAgainst(t *testing.T, tree *OrderStatisticTree, reference []int) {
+	t.Helper()
+	checkOST(t, tree.root)
+	if tree.Size() != len(reference) {
+		t.Fatalf("expected size %d, got %d", len(reference), tree.Size())
+	}
+	for k, want := range reference {
+		if v := tree.Select(k); v != want {
+			t.Fatalf("expected Select(%d) = %d, got %v", k, want, v)
+		}
+	}
+	for v := -1; v <= 101; v++ {
+		if rank, want := tree.Rank(v), sort.SearchInts(reference, v); rank != want {
+			t.Fatalf("expected Rank(%d) = %d, got %d", v, want, rank)
+		}
+	}
+}
+
+func TestOrderStatisticTree(t *testing.T) {
+	tree := NewOrderStatisticTree(intCompare)
+	if !tree.Empty() || tree.Height() != -1 || tree.Select(0) != nil {
+		t.Fatal("expected new tree to be empty")
+	}
+
+	r := rand.New(rand.NewSource(1))
+	var reference []int
+	for _, v := range r.Perm(100) {
+		// Insert some duplicates too.
+		for i := 0; i <= v%3/2; i++ {
+			tree.Insert(v)
+			reference = append(reference, v)
+			sort.Ints(reference)
+			checkOSTAgainst(t, tree, reference)
+		}
+	}
+	if h := tree.Height(); h > 9 {
+		t.Errorf("expected logarithmic height, got %d", h)
+	}
+
+	var visited []int
+	tree.RangeWithKey(func(v interface{}) bool {
+		visited = append(visited, v.(int))
+		return true
+	})
+	if len(visited) != len(reference) || !sort.IntsAreSorted(visited) {
+		t.Errorf("expected in-order iteration, got %v", visited)
+	}
+
+	if tree.Delete(1000) {
+		t.Error("expected Delete of a missing element to fail")
+	}
+	for len(reference) > 0 {
+		i := r.Intn(len(reference))
+		if !tree.Delete(reference[i]) {
+			t.Fatalf("expected %d to be deleted", reference[i])
+		}
+		reference = append(reference[:i], reference[i+1:]...)
+		checkOSTAgainst(t, tree, reference)
+	}
+	if !tree.Empty() {
+		t.Error("expected tree to be empty after deleting all")
+	}
+
+	tree.Insert(1)
+	if !tree.Has(1) || tree.Has(2) || tree.Select(-1) != nil || tree.Select(1) != nil {
+		t.Error("expected single element tree")
+	}
+	tree.Clear()
+	if !tree.Empty() {
+		t.Error("expected tree to be empty after Clear")
+	}
+}
+
+func TestOrderStatisticTreeSerialize(t *testing.T) {
+	tree := NewOrderStatisticTree(intCompare)
+	for _, v := range rand.New(rand.NewSource(2)).Perm(1000) {
+		tree.Insert(v % 700)
+	}
+	data, err := tree.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored := NewOrderStatisticTree(intCompare)
+	restored.Insert(-1)
+	if err := restored.Deserialize(data); err != nil {
+		t.Fatal(err)
+	}
+	checkOST(t, restored.root)
+	if restored.Size() != tree.Size() {
+		t.Fatalf("expected size %d, got %d", tree.Size(), restored.Size())
+	}
+	if h := restored.Height(); h != 9 {
+		t.Errorf("expected a perfectly balanced height of 9, got %d", h)
+	}
+	for k := 0; k < tree.Size(); k++ {
+		if a, b := tree.Select(k), restored.Select(k); a != b {
+			t.Fatalf("expected Select(%d) = %v, got %v", k, a, b)
+		}
+	}
+
+	descending := NewOrderStatisticTree(func(a, b interface{}) int { return intCompare(b, a) })
+	if err := descending.Deserialize(data); err == nil || !descending.Empty() {
+		t.Error("expected elements out of order to be rejected")
+	}
+	if err := restored.Deserialize([]byte("garbage")); err == nil || restored.Size() != tree.Size() {
+		t.Error("expected garbage to be rejected")
+	}
+}
+
+// ostChain hand-builds the subtree of nodes, each one being the parent of
+// the next one, on its left if the next one is lower.
+func ostChain(values ...int) *ostNode {
+	root := &ostNode{value: values[0]}
+	parent := root
+	for _, v := range values[1:] {
+		n := &ostNode{value: v}
+		if v < parent.value.(int) {
+			parent.left = n
+		} else {
+			parent.right = n
+		}
+		parent = n
+	}
+	return root
+}
+
+func TestOrderStatisticTreeShape(t *testing.T) {
+	tree := NewOrderStatisticTree(intCompare)
+	if tree.Height() != -1 || tree.MinHeight() != -1 || tree.Diameter() != -1 || !tree.IsBalanced() {
+		t.Error("expected an empty tree to have no path and be balanced")
+	}
+
+	// A perfectly balanced tree of height 2.
+	for _, v := range []int{4, 2, 6, 1, 3, 5, 7} {
+		tree.Insert(v)
+	}
+	if tree.Height() != 2 || tree.MinHeight() != 2 || tree.Diameter() != 4 || !tree.IsBalanced() {
+		t.Errorf("expected heights 2 and 2 and diameter 4, got %d and %d and %d",
+			tree.Height(), tree.MinHeight(), tree.Diameter())
+	}
+
+	// A skewed tree: 1 -> 2 -> 3 -> 4 to the right, with 0 left of 1.
+	tree.root = ostChain(1, 2, 3, 4)
+	tree.root.left = &ostNode{value: 0}
+	if tree.MinHeight() != 1 || tree.Diameter() != 4 || tree.IsBalanced() {
+		t.Errorf("expected min height 1, diameter 4 and unbalanced, got %d, %d and %v",
+			tree.MinHeight(), tree.Diameter(), tree.IsBalanced())
+	}
+
+	// The longest path does not pass through the root: 10 is the root, and
+	// the path runs between the leaves 1 and 8 of its left subtree.
+	tree.root = &ostNode{value: 10, left: ostChain(5, 3, 2, 1)}
+	tree.root.left.right = ostChain(6, 7, 8)
+	if tree.Diameter() != 6 || tree.MinHeight() != 4 || tree.IsBalanced() {
+		t.Errorf("expected diameter 6 and min height 4, got %d and %d", tree.Diameter(), tree.MinHeight())
+	}
+
+	// Only child paths lead to leaves.
+	tree.root = ostChain(1, 2)
+	if tree.MinHeight() != 1 || !tree.IsBalanced() {
+		t.Errorf("expected min height 1 and balanced, got %d", tree.MinHeight())
+	}
+}
+
+func TestOrderStatisticTreeSearch(t *testing.T) {
+	tree := NewOrderStatisticTree(intCompare)
+	for _, v := range []int{4, 2, 6, 1, 3, 5, 7} {
+		tree.Insert(v)
+	}
+	tests := []struct {
+		key  int
+		path []interface{}
+	}{
+		{4, []interface{}{4}},
+		{2, []interface{}{4, 2}},
+		{5, []interface{}{4, 6, 5}},
+		{7, []interface{}{4, 6, 7}},
+	}
+	for _, tt := range tests {
+		path, found := tree.Search(tt.key)
+		if !found || !equalRaw(path.Raw(), tt.path) {
+			t.Errorf("Search(%d) = (%v, %v), expected (%v, true)", tt.key, path.Raw(), found, tt.path)
+		}
+	}
+	for _, key := range []int{0, 8} {
+		if path, found := tree.Search(key); found || !path.Empty() {
+			t.Errorf("Search(%d) = (%v, %v), expected an empty path", key, path.Raw(), found)
+		}
+	}
+	if path, found := NewOrderStatisticTree(intCompare).Search(1); found || !path.Empty() {
+		t.Error("expected nothing found in an empty tree")
+	}
+}
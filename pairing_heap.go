@@ -0,0 +1,162 @@
+package gods
+
+// PairingHeap is a PriorityQueue backed by a pairing heap, which pops the
+// least element according to its comparison function first. It supports
+// decreasing an element through the handle returned when pushing it, in
+// O(1) amortized time, which makes it a good fit for graph algorithms such
+// as Dijkstra's. Push is O(1) and Pop is O(log n) amortized.
+type PairingHeap struct {
+	cmp   func(a, b interface{}) int
+	root  *PairingHeapNode
+	size  int
+	owner *struct{} // replaced on Clear to invalidate all the handles
+}
+
+// PairingHeapNode is a handle on an element of a PairingHeap.
+type PairingHeapNode struct {
+	value   interface{}
+	owner   *struct{}
+	child   *PairingHeapNode // leftmost child
+	sibling *PairingHeapNode // next sibling
+	prev    *PairingHeapNode // previous sibling, or parent of the leftmost child
+}
+
+// Value returns the element held by the node.
+func (n *PairingHeapNode) Value() interface{} {
+	return n.value
+}
+
+var _ PriorityQueue = (*PairingHeap)(nil)
+
+// NewPairingHeap creates an empty PairingHeap ordered by cmp, which returns
+// a negative number if a has a higher priority than b, zero if they have the
+// same priority, and a positive number otherwise. Pass a reversed comparison
+// function to pop the greatest element first.
+func NewPairingHeap(cmp func(a, b interface{}) int) *PairingHeap {
+	return &PairingHeap{cmp: cmp, owner: new(struct{})}
+}
+
+// Empty indicates if the PairingHeap is empty.
+func (h *PairingHeap) Empty() bool {
+	return h.size == 0
+}
+
+// Size retrieves PairingHeap size.
+func (h *PairingHeap) Size() int {
+	return h.size
+}
+
+// Clear resets PairingHeap, it will be empty with size 0. All the handles
+// on its former elements become invalid.
+func (h *PairingHeap) Clear() {
+	h.root = nil
+	h.size = 0
+	h.owner = new(struct{})
+}
+
+// Peek inspects the highest priority element of PairingHeap without
+// removing it. Returns (nil, false) if the PairingHeap is empty.
+func (h *PairingHeap) Peek() (interface{}, bool) {
+	if h.root == nil {
+		return nil, false
+	}
+	return h.root.value, true
+}
+
+// Push adds an element to the PairingHeap.
+func (h *PairingHeap) Push(v interface{}) {
+	h.Insert(v)
+}
+
+// Insert adds an element to the PairingHeap and returns a handle on it,
+// which can be passed to DecreaseKey.
+func (h *PairingHeap) Insert(v interface{}) *PairingHeapNode {
+	n := &PairingHeapNode{value: v, owner: h.owner}
+	h.root = h.meld(h.root, n)
+	h.size++
+	return n
+}
+
+// Pop ejects the highest priority element of PairingHeap, and removes it.
+// Returns nil if the PairingHeap is empty.
+func (h *PairingHeap) Pop() interface{} {
+	root := h.root
+	if root == nil {
+		return nil
+	}
+	h.root = h.mergePairs(root.child)
+	h.size--
+	*root = PairingHeapNode{value: root.value}
+	return root.value
+}
+
+// DecreaseKey replaces the element of node with v, which must have a
+// priority at least as high as the one it replaces. Panics if node is not
+// in the PairingHeap or v has a lower priority.
+func (h *PairingHeap) DecreaseKey(node *PairingHeapNode, v interface{}) {
+	if node.owner != h.owner {
+		panic("gods: node is not in the PairingHeap")
+	}
+	if mustCompare(v, node.value, h.cmp) > 0 {
+		panic("gods: DecreaseKey can not lower the priority of an element")
+	}
+	node.value = v
+	if node == h.root {
+		return
+	}
+	// Cut the subtree of node and meld it back with the root.
+	if node.prev.child == node {
+		node.prev.child = node.sibling
+	} else {
+		node.prev.sibling = node.sibling
+	}
+	if node.sibling != nil {
+		node.sibling.prev = node.prev
+	}
+	node.prev, node.sibling = nil, nil
+	h.root = h.meld(h.root, node)
+}
+
+// meld links the roots a and b, the one with the lower priority becoming
+// the leftmost child of the other.
+func (h *PairingHeap) meld(a, b *PairingHeapNode) *PairingHeapNode {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if mustCompare(b.value, a.value, h.cmp) < 0 {
+		a, b = b, a
+	}
+	b.prev = a
+	b.sibling = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	return a
+}
+
+// mergePairs melds a list of siblings into a single root with the standard
+// two-pass strategy: meld pairs from left to right, then meld the results
+// from right to left.
+func (h *PairingHeap) mergePairs(first *PairingHeapNode) *PairingHeapNode {
+	var pairs []*PairingHeapNode
+	for first != nil {
+		a, b := first, first.sibling
+		if b == nil {
+			first = nil
+		} else {
+			first = b.sibling
+			b.prev, b.sibling = nil, nil
+		}
+		a.prev, a.sibling = nil, nil
+		pairs = append(pairs, h.meld(a, b))
+	}
+	var root *PairingHeapNode
+	for i := len(pairs) - 1; i >= 0; i-- {
+		root = h.meld(pairs[i], root)
+	}
+	return root
+}
@@ -0,0 +1,142 @@
+package gods
+
+import (
+	"container/heap"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestPairingHeap(t *testing.T) {
+	h := NewPairingHeap(intCompare)
+	if !h.Empty() || h.Pop() != nil {
+		t.Fatal("expected new heap to be empty")
+	}
+	if _, ok := h.Peek(); ok {
+		t.Error("expected Peek on empty heap to fail")
+	}
+
+	r := rand.New(rand.NewSource(1))
+	var reference []int
+	for i := 0; i < 1000; i++ {
+		v := r.Intn(500)
+		h.Push(v)
+		reference = append(reference, v)
+	}
+	sort.Ints(reference)
+	if h.Size() != len(reference) {
+		t.Fatalf("expected size %d, got %d", len(reference), h.Size())
+	}
+	for i, want := range reference {
+		if v, _ := h.Peek(); v != want {
+			t.Fatalf("expected Peek to return %d at %d, got %v", want, i, v)
+		}
+		if v := h.Pop(); v != want {
+			t.Fatalf("expected %d at %d, got %v", want, i, v)
+		}
+	}
+	if !h.Empty() {
+		t.Error("expected heap to be empty after popping all")
+	}
+}
+
+func TestPairingHeapDecreaseKey(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	h := NewPairingHeap(intCompare)
+	nodes := make([]*PairingHeapNode, 0, 500)
+	for i := 0; i < 500; i++ {
+		nodes = append(nodes, h.Insert(r.Intn(10000)))
+	}
+	for i := 0; i < 200; i++ {
+		// Interleave pops to exercise decrease-key in a restructured heap.
+		if i%20 == 0 {
+			popped := h.Pop()
+			for j, n := range nodes {
+				if n.Value() == popped && n.owner == nil {
+					nodes = append(nodes[:j], nodes[j+1:]...)
+					break
+				}
+			}
+		}
+		n := nodes[r.Intn(len(nodes))]
+		h.DecreaseKey(n, n.Value().(int)-r.Intn(5000))
+	}
+
+	var reference []int
+	for _, n := range nodes {
+		reference = append(reference, n.Value().(int))
+	}
+	sort.Ints(reference)
+	for i, want := range reference {
+		if v := h.Pop(); v != want {
+			t.Fatalf("expected %d at %d, got %v", want, i, v)
+		}
+	}
+}
+
+func TestPairingHeapDecreaseKeyPanics(t *testing.T) {
+	h := NewPairingHeap(intCompare)
+	n := h.Insert(5)
+	for name, fn := range map[string]func(){
+		"increase":   func() { h.DecreaseKey(n, 6) },
+		"other heap": func() { NewPairingHeap(intCompare).DecreaseKey(n, 1) },
+		"popped":     func() { m := h.Insert(0); h.Pop(); h.DecreaseKey(m, -1) },
+		"cleared":    func() { h.Clear(); h.DecreaseKey(n, 1) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for %s", name)
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+func BenchmarkPairingHeapDecreaseKey(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	cmp := func(a, b interface{}) int {
+		switch x, y := a.(float64), b.(float64); {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	for i := 0; i < b.N; i++ {
+		h := NewPairingHeap(cmp)
+		nodes := make([]*PairingHeapNode, 1000)
+		for j := range nodes {
+			nodes[j] = h.Insert(r.Float64() * 1000)
+		}
+		for j := 0; j < 10000; j++ {
+			n := nodes[r.Intn(len(nodes))]
+			h.DecreaseKey(n, n.Value().(float64)-r.Float64())
+		}
+		for !h.Empty() {
+			h.Pop()
+		}
+	}
+}
+
+func BenchmarkIndexedHeapDecreaseKey(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < b.N; i++ {
+		var h priorityEntries
+		entries := make([]*priorityEntry, 1000)
+		for j := range entries {
+			entries[j] = &priorityEntry{priority: r.Float64() * 1000}
+			heap.Push(&h, entries[j])
+		}
+		for j := 0; j < 10000; j++ {
+			e := entries[r.Intn(len(entries))]
+			e.priority -= r.Float64()
+			heap.Fix(&h, e.index)
+		}
+		for h.Len() > 0 {
+			heap.Pop(&h)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package gods
+
+// IsPalindrome checks whether s reads the same forward and backward,
+// comparing elements with ==. Empty and single element Slices are
+// palindromes.
+func IsPalindrome(s Slice) bool {
+	return IsPalindromeBy(s, func(a, b interface{}) bool { return a == b })
+}
+
+// IsPalindromeBy is like IsPalindrome, but compares elements with eq.
+func IsPalindromeBy(s Slice, eq func(a, b interface{}) bool) bool {
+	raw := s.Raw()
+	// Compare both ends inward, like popping a Deque from the front and
+	// the back, without modifying s.
+	for front, back := 0, len(raw)-1; front < back; front, back = front+1, back-1 {
+		if !eq(raw[front], raw[back]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,38 @@
+package gods
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsPalindrome(t *testing.T) {
+	tests := []struct {
+		s    *ArraySlice
+		want bool
+	}{
+		{NewArraySlice(), true},
+		{NewArraySlice(1), true},
+		{NewArraySlice(1, 1), true},
+		{NewArraySlice(1, 2, 2, 1), true},
+		{NewArraySlice(1, 2, 3, 2, 1), true},
+		{NewArraySlice(1, 2), false},
+		{NewArraySlice(1, 2, 3, 1), false},
+		{NewArraySlice(1, 2, 3, 4, 1), false},
+		{NewArraySlice("a", 1, "a"), true},
+	}
+	for _, tt := range tests {
+		if got := IsPalindrome(tt.s); got != tt.want {
+			t.Errorf("IsPalindrome(%v) = %v, expected %v", tt.s.Raw(), got, tt.want)
+		}
+	}
+}
+
+func TestIsPalindromeBy(t *testing.T) {
+	eqFold := func(a, b interface{}) bool { return strings.EqualFold(a.(string), b.(string)) }
+	if !IsPalindromeBy(NewArraySlice("R", "a", "c", "E", "c", "A", "r"), eqFold) {
+		t.Error("expected case insensitive palindrome")
+	}
+	if IsPalindromeBy(NewArraySlice("R", "a", "c", "e"), eqFold) {
+		t.Error("expected non palindrome")
+	}
+}
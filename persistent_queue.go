@@ -0,0 +1,152 @@
+package gods
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// persistentHeaderSize is the size of the PersistentQueue log header, which
+// holds the offset of the first record not popped yet.
+const persistentHeaderSize = 8
+
+// PersistentQueue is a FIFO queue backed by an append-only log, so that the
+// elements pushed and not popped yet can be recovered after a restart. The
+// log starts with a header holding the offset of the start element, followed
+// by a record per pushed element: its encoding prefixed by its length. Push
+// appends a record and Pop rewrites the header, so the log only grows.
+type PersistentQueue struct {
+	w      io.WriteSeeker
+	encode func(interface{}) ([]byte, error)
+	items  []interface{}
+	// sizes are the record sizes of items, to advance head on Pop.
+	sizes []int64
+	head  int64
+}
+
+// NewPersistentQueue creates an empty PersistentQueue logging to w, and
+// encoding the pushed elements with encode. Use Recover to load the
+// elements of an existing log.
+func NewPersistentQueue(w io.WriteSeeker, encode func(interface{}) ([]byte, error)) *PersistentQueue {
+	return &PersistentQueue{w: w, encode: encode, head: persistentHeaderSize}
+}
+
+// Empty indicates if the PersistentQueue is empty.
+func (q *PersistentQueue) Empty() bool {
+	return len(q.items) == 0
+}
+
+// Size retrieves PersistentQueue size.
+func (q *PersistentQueue) Size() int {
+	return len(q.items)
+}
+
+// Peek inspects the start element of PersistentQueue without removing it.
+// Returns (nil, false) if the PersistentQueue is empty.
+func (q *PersistentQueue) Peek() (interface{}, bool) {
+	if len(q.items) == 0 {
+		return nil, false
+	}
+	return q.items[0], true
+}
+
+// Push encodes v and appends its record to the log, then to the end of
+// PersistentQueue. v is not enqueued if encoding or writing fails.
+func (q *PersistentQueue) Push(v interface{}) error {
+	data, err := q.encode(v)
+	if err != nil {
+		return err
+	}
+	end, err := q.w.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	if end == 0 {
+		if err := q.writeHeader(); err != nil {
+			return err
+		}
+	}
+	record := make([]byte, 4+len(data))
+	binary.BigEndian.PutUint32(record, uint32(len(data)))
+	copy(record[4:], data)
+	if _, err := q.w.Write(record); err != nil {
+		return err
+	}
+	q.items = append(q.items, v)
+	q.sizes = append(q.sizes, int64(len(record)))
+	return nil
+}
+
+// Pop ejects the start element of PersistentQueue and advances the log
+// header past its record. Returns (nil, nil) if the PersistentQueue is
+// empty, and keeps the element enqueued if writing the header fails.
+func (q *PersistentQueue) Pop() (interface{}, error) {
+	if len(q.items) == 0 {
+		return nil, nil
+	}
+	q.head += q.sizes[0]
+	if err := q.writeHeader(); err != nil {
+		q.head -= q.sizes[0]
+		return nil, err
+	}
+	v := q.items[0]
+	q.items[0] = nil
+	q.items, q.sizes = q.items[1:], q.sizes[1:]
+	return v, nil
+}
+
+func (q *PersistentQueue) writeHeader() error {
+	if _, err := q.w.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	var header [persistentHeaderSize]byte
+	binary.BigEndian.PutUint64(header[:], uint64(q.head))
+	_, err := q.w.Write(header[:])
+	return err
+}
+
+// Recover replaces the elements of PersistentQueue with those read from r,
+// the content of its log, decoding the records not popped yet with decode.
+// An empty r is an empty log. The PersistentQueue is unchanged if reading
+// or decoding fails, and a record truncated by a crash while pushing is an
+// error wrapping io.ErrUnexpectedEOF.
+func (q *PersistentQueue) Recover(r io.Reader, decode func([]byte) (interface{}, error)) error {
+	var header [persistentHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err == io.EOF {
+		q.items, q.sizes, q.head = nil, nil, persistentHeaderSize
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("gods: reading PersistentQueue header: %w", err)
+	}
+	head := int64(binary.BigEndian.Uint64(header[:]))
+
+	var items []interface{}
+	var sizes []int64
+	var length [4]byte
+	for offset := int64(persistentHeaderSize); ; {
+		if _, err := io.ReadFull(r, length[:]); err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf("gods: reading PersistentQueue record at %d: %w", offset, err)
+		}
+		data := make([]byte, binary.BigEndian.Uint32(length[:]))
+		if _, err := io.ReadFull(r, data); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return fmt.Errorf("gods: reading PersistentQueue record at %d: %w", offset, err)
+		}
+		size := int64(len(length) + len(data))
+		if offset >= head {
+			v, err := decode(data)
+			if err != nil {
+				return fmt.Errorf("gods: decoding PersistentQueue record at %d: %w", offset, err)
+			}
+			items = append(items, v)
+			sizes = append(sizes, size)
+		}
+		offset += size
+	}
+	q.items, q.sizes, q.head = items, sizes, head
+	return nil
+}
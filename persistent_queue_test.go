@@ -0,0 +1,124 @@
+package gods
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func encodeInt(v interface{}) ([]byte, error) {
+	return []byte(strconv.Itoa(v.(int))), nil
+}
+
+func decodeInt(data []byte) (interface{}, error) {
+	return strconv.Atoi(string(data))
+}
+
+// openPersistentQueue opens the log at path and recovers a PersistentQueue
+// from it, as on startup.
+func openPersistentQueue(t *testing.T, path string) (*PersistentQueue, *os.File) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.Close() })
+	q := NewPersistentQueue(f, encodeInt)
+	if err := q.Recover(f, decodeInt); err != nil {
+		t.Fatalf("unexpected Recover error %v", err)
+	}
+	return q, f
+}
+
+func TestPersistentQueueRecover(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.log")
+	q, f := openPersistentQueue(t, path)
+	if !q.Empty() {
+		t.Fatalf("expected a new log to recover an empty queue, got size %d", q.Size())
+	}
+	for i := 1; i <= 5; i++ {
+		if err := q.Push(i * 10); err != nil {
+			t.Fatalf("unexpected Push error %v", err)
+		}
+	}
+	for _, want := range []int{10, 20} {
+		if v, err := q.Pop(); err != nil || v != want {
+			t.Fatalf("expected to pop %d, got (%v, %v)", want, v, err)
+		}
+	}
+	f.Close()
+
+	// Restart, and keep using the recovered queue.
+	q, f = openPersistentQueue(t, path)
+	if got := q.Size(); got != 3 {
+		t.Fatalf("expected 3 elements recovered, got %d", got)
+	}
+	if v, _ := q.Peek(); v != 30 {
+		t.Errorf("expected 30 at the start, got %v", v)
+	}
+	if v, err := q.Pop(); err != nil || v != 30 {
+		t.Fatalf("expected to pop 30, got (%v, %v)", v, err)
+	}
+	if err := q.Push(60); err != nil {
+		t.Fatalf("unexpected Push error %v", err)
+	}
+	f.Close()
+
+	q, _ = openPersistentQueue(t, path)
+	var got []interface{}
+	for !q.Empty() {
+		v, err := q.Pop()
+		if err != nil {
+			t.Fatalf("unexpected Pop error %v", err)
+		}
+		got = append(got, v)
+	}
+	if !equalRaw(got, []interface{}{40, 50, 60}) {
+		t.Errorf("expected [40 50 60] recovered in order, got %v", got)
+	}
+	if v, err := q.Pop(); v != nil || err != nil {
+		t.Errorf("expected (nil, nil) popping an empty queue, got (%v, %v)", v, err)
+	}
+}
+
+func TestPersistentQueueRecoverTruncated(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.log")
+	q, f := openPersistentQueue(t, path)
+	q.Push(1)
+	q.Push(2)
+	info, err := f.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Simulate a crash in the middle of writing the last record.
+	if err := f.Truncate(info.Size() - 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+
+	recovered := NewPersistentQueue(f, encodeInt)
+	if err := recovered.Recover(f, decodeInt); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+	if !recovered.Empty() {
+		t.Error("expected a failed Recover to leave the queue unchanged")
+	}
+}
+
+func TestPersistentQueuePushEncodeError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.log")
+	_, f := openPersistentQueue(t, path)
+	errEncode := errors.New("not an int")
+	q := NewPersistentQueue(f, func(interface{}) ([]byte, error) { return nil, errEncode })
+	if err := q.Push(1); err != errEncode {
+		t.Errorf("expected the encode error, got %v", err)
+	}
+	if !q.Empty() {
+		t.Error("expected the element not to be enqueued")
+	}
+}
@@ -0,0 +1,113 @@
+package gods
+
+// PrefixMap is a trie based Container mapping string keys to values, which
+// supports looking up the longest stored key that prefixes a query, the core
+// operation of a routing table.
+type PrefixMap struct {
+	root *prefixNode
+	size int
+}
+
+type prefixNode struct {
+	children map[byte]*prefixNode
+	value    interface{}
+	hasValue bool
+}
+
+// NewPrefixMap creates an empty PrefixMap.
+func NewPrefixMap() *PrefixMap {
+	return &PrefixMap{root: &prefixNode{}}
+}
+
+// Empty indicates if the PrefixMap is empty.
+func (m *PrefixMap) Empty() bool {
+	return m.size == 0
+}
+
+// Size retrieves the number of keys in the PrefixMap.
+func (m *PrefixMap) Size() int {
+	return m.size
+}
+
+// Clear resets PrefixMap, it will be empty with size 0.
+func (m *PrefixMap) Clear() {
+	m.root = &prefixNode{}
+	m.size = 0
+}
+
+// Put maps key to value, replacing any value previously mapped to key.
+func (m *PrefixMap) Put(key string, value interface{}) {
+	n := m.root
+	for i := 0; i < len(key); i++ {
+		child := n.children[key[i]]
+		if child == nil {
+			if n.children == nil {
+				n.children = make(map[byte]*prefixNode)
+			}
+			child = &prefixNode{}
+			n.children[key[i]] = child
+		}
+		n = child
+	}
+	if !n.hasValue {
+		m.size++
+	}
+	n.value, n.hasValue = value, true
+}
+
+// Get finds the value (if any) that is bound to key.
+func (m *PrefixMap) Get(key string) (interface{}, bool) {
+	n := m.root
+	for i := 0; i < len(key) && n != nil; i++ {
+		n = n.children[key[i]]
+	}
+	if n == nil || !n.hasValue {
+		return nil, false
+	}
+	return n.value, true
+}
+
+// Has checks whether key is in the PrefixMap.
+func (m *PrefixMap) Has(key string) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Delete removes key and its value from the PrefixMap, if present.
+func (m *PrefixMap) Delete(key string) {
+	path := make([]*prefixNode, 0, len(key)+1)
+	n := m.root
+	for i := 0; i < len(key) && n != nil; i++ {
+		path = append(path, n)
+		n = n.children[key[i]]
+	}
+	if n == nil || !n.hasValue {
+		return
+	}
+	n.value, n.hasValue = nil, false
+	m.size--
+
+	// Prune the nodes which no longer lead to any value.
+	for i := len(path) - 1; i >= 0 && !n.hasValue && len(n.children) == 0; i-- {
+		delete(path[i].children, key[i])
+		n = path[i]
+	}
+}
+
+// LongestPrefix finds the longest key in the PrefixMap which is a prefix of
+// key, and returns it along with its value. Returns ("", nil, false) if no
+// key prefixes key.
+func (m *PrefixMap) LongestPrefix(key string) (matchedPrefix string, value interface{}, ok bool) {
+	n := m.root
+	for i := 0; ; i++ {
+		if n.hasValue {
+			matchedPrefix, value, ok = key[:i], n.value, true
+		}
+		if i == len(key) {
+			return
+		}
+		if n = n.children[key[i]]; n == nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package gods
+
+import "testing"
+
+func TestPrefixMap(t *testing.T) {
+	m := NewPrefixMap()
+	if !m.Empty() {
+		t.Fatal("expected new map to be empty")
+	}
+	m.Put("/a", 1)
+	m.Put("/a/b", 2)
+	m.Put("/a", 3)
+	if m.Size() != 2 {
+		t.Fatalf("expected size 2, got %d", m.Size())
+	}
+	if v, ok := m.Get("/a"); !ok || v != 3 {
+		t.Errorf("expected /a to map to 3, got %v", v)
+	}
+	if m.Has("/a/") || m.Has("") {
+		t.Error("expected only stored keys to be present")
+	}
+
+	m.Delete("/a/b")
+	m.Delete("/missing")
+	if m.Size() != 1 || m.Has("/a/b") || !m.Has("/a") {
+		t.Error("expected only /a/b to be deleted")
+	}
+	if len(m.root.children) != 1 || len(m.root.children['/'].children['a'].children) != 0 {
+		t.Error("expected nodes of deleted key to be pruned")
+	}
+
+	m.Clear()
+	if !m.Empty() || m.Has("/a") {
+		t.Error("expected map to be empty after Clear")
+	}
+}
+
+func TestPrefixMapLongestPrefix(t *testing.T) {
+	m := NewPrefixMap()
+	m.Put("/a", "a")
+	m.Put("/a/b", "ab")
+	m.Put("/a/b/c/d", "abcd")
+
+	tests := []struct {
+		key, prefix string
+		value       interface{}
+		ok          bool
+	}{
+		{"/a/b/c", "/a/b", "ab", true},
+		{"/a/b", "/a/b", "ab", true},
+		{"/a/x", "/a", "a", true},
+		{"/a/b/c/d/e", "/a/b/c/d", "abcd", true},
+		{"/b", "", nil, false},
+		{"", "", nil, false},
+	}
+	for _, tt := range tests {
+		prefix, value, ok := m.LongestPrefix(tt.key)
+		if prefix != tt.prefix || value != tt.value || ok != tt.ok {
+			t.Errorf("LongestPrefix(%q) = (%q, %v, %v), expected (%q, %v, %v)",
+				tt.key, prefix, value, ok, tt.prefix, tt.value, tt.ok)
+		}
+	}
+
+	m.Put("", "root")
+	if prefix, value, ok := m.LongestPrefix("/b"); prefix != "" || value != "root" || !ok {
+		t.Errorf("expected empty key to match, got (%q, %v, %v)", prefix, value, ok)
+	}
+}
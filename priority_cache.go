@@ -0,0 +1,128 @@
+package gods
+
+import "container/heap"
+
+// PriorityCache is a bounded Container mapping keys to values, each with an
+// eviction priority. When a Put exceeds the capacity, the entry with the
+// lowest priority is evicted in O(log n). Entries of equal priority are
+// evicted in no particular order.
+type PriorityCache struct {
+	capacity int
+	entries  map[interface{}]*priorityEntry
+	heap     priorityEntries
+}
+
+type priorityEntry struct {
+	key      interface{}
+	value    interface{}
+	priority float64
+	index    int // position in the heap, maintained by priorityEntries
+}
+
+// priorityEntries is a min-heap of entries by priority, implementing
+// heap.Interface.
+type priorityEntries []*priorityEntry
+
+func (h priorityEntries) Len() int           { return len(h) }
+func (h priorityEntries) Less(i, j int) bool { return h[i].priority < h[j].priority }
+
+func (h priorityEntries) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *priorityEntries) Push(x interface{}) {
+	e := x.(*priorityEntry)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *priorityEntries) Pop() interface{} {
+	old := *h
+	e := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return e
+}
+
+// NewPriorityCache creates an empty PriorityCache holding at most capacity
+// entries. Panics if capacity is not positive.
+func NewPriorityCache(capacity int) *PriorityCache {
+	if capacity <= 0 {
+		panic("gods: PriorityCache capacity must be positive")
+	}
+	return &PriorityCache{
+		capacity: capacity,
+		entries:  make(map[interface{}]*priorityEntry),
+	}
+}
+
+// Empty indicates if the PriorityCache is empty.
+func (c *PriorityCache) Empty() bool {
+	return len(c.entries) == 0
+}
+
+// Size retrieves PriorityCache size.
+func (c *PriorityCache) Size() int {
+	return len(c.entries)
+}
+
+// Clear resets PriorityCache, it will be empty with size 0.
+func (c *PriorityCache) Clear() {
+	c.entries = make(map[interface{}]*priorityEntry)
+	c.heap = nil
+}
+
+// Put maps key to value with the given eviction priority. If key is already
+// in the PriorityCache, its value and priority are updated. If the
+// capacity is exceeded, the entry with the lowest priority, possibly the
+// new one, is evicted.
+func (c *PriorityCache) Put(key, value interface{}, priority float64) {
+	if e, ok := c.entries[key]; ok {
+		e.value, e.priority = value, priority
+		heap.Fix(&c.heap, e.index)
+		return
+	}
+	e := &priorityEntry{key: key, value: value, priority: priority}
+	c.entries[key] = e
+	heap.Push(&c.heap, e)
+	if len(c.heap) > c.capacity {
+		evicted := heap.Pop(&c.heap).(*priorityEntry)
+		delete(c.entries, evicted.key)
+	}
+}
+
+// Get finds the value (if any) that is bound to key.
+func (c *PriorityCache) Get(key interface{}) (interface{}, bool) {
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	return e.value, true
+}
+
+// Priority finds the eviction priority (if any) of key.
+func (c *PriorityCache) Priority(key interface{}) (float64, bool) {
+	e, ok := c.entries[key]
+	if !ok {
+		return 0, false
+	}
+	return e.priority, true
+}
+
+// Has checks whether key is in the PriorityCache.
+func (c *PriorityCache) Has(key interface{}) bool {
+	_, ok := c.entries[key]
+	return ok
+}
+
+// Delete removes the entry of key, if present.
+func (c *PriorityCache) Delete(key interface{}) {
+	e, ok := c.entries[key]
+	if !ok {
+		return
+	}
+	heap.Remove(&c.heap, e.index)
+	delete(c.entries, key)
+}
@@ -0,0 +1,61 @@
+package gods
+
+import "testing"
+
+func TestPriorityCache(t *testing.T) {
+	c := NewPriorityCache(3)
+	if !c.Empty() {
+		t.Fatal("expected new cache to be empty")
+	}
+	c.Put("a", 1, 5)
+	c.Put("b", 2, 1)
+	c.Put("c", 3, 3)
+	c.Put("d", 4, 4)
+	if c.Size() != 3 || c.Has("b") {
+		t.Fatal("expected lowest priority entry b to be evicted")
+	}
+
+	c.Put("e", 5, 0)
+	if c.Has("e") || c.Size() != 3 {
+		t.Error("expected new entry with the lowest priority to be evicted")
+	}
+
+	// Lower the priority of a, it becomes the next one to be evicted.
+	c.Put("a", 10, 2)
+	if v, _ := c.Get("a"); v != 10 {
+		t.Errorf("expected Put to update the value, got %v", v)
+	}
+	if p, _ := c.Priority("a"); p != 2 {
+		t.Errorf("expected Put to update the priority, got %v", p)
+	}
+	c.Put("f", 6, 6)
+	if c.Has("a") || !c.Has("c") || !c.Has("d") || !c.Has("f") {
+		t.Error("expected a to be evicted after lowering its priority")
+	}
+
+	// Raise the priority of c, d becomes the next one to be evicted.
+	c.Put("c", 3, 9)
+	c.Put("g", 7, 7)
+	if c.Has("d") || !c.Has("c") {
+		t.Error("expected d to be evicted after raising the priority of c")
+	}
+
+	c.Delete("c")
+	c.Delete("missing")
+	if c.Size() != 2 || c.Has("c") {
+		t.Error("expected c to be deleted")
+	}
+	if _, ok := c.Get("c"); ok {
+		t.Error("expected Get of a deleted key to fail")
+	}
+	c.Put("h", 8, 0)
+	c.Put("i", 9, 1)
+	if c.Has("h") {
+		t.Error("expected heap to stay consistent after Delete")
+	}
+
+	c.Clear()
+	if !c.Empty() {
+		t.Error("expected cache to be empty after Clear")
+	}
+}
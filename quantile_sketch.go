@@ -0,0 +1,138 @@
+package gods
+
+import (
+	"math"
+	"sort"
+)
+
+// QuantileSketch summarizes a stream of numbers in bounded memory to
+// estimate its quantiles, using a merging t-digest. Values are clustered
+// into centroids which are kept small near the extremes, so tail quantiles
+// like p99 are estimated more precisely than the median. The rank of an
+// estimated quantile is typically within epsilon of the exact one, with
+// O(1/epsilon) centroids.
+type QuantileSketch struct {
+	compression float64
+	centroids   []centroid // merged, sorted by mean
+	buffer      []centroid // added since the last merge
+	count       float64
+	min, max    float64
+}
+
+type centroid struct {
+	mean, count float64
+}
+
+// NewQuantileSketch creates an empty QuantileSketch with the given relative
+// rank error. Panics if epsilon is not in the (0, 1) range.
+func NewQuantileSketch(epsilon float64) *QuantileSketch {
+	if !(epsilon > 0 && epsilon < 1) {
+		panic("gods: QuantileSketch epsilon must be in (0, 1)")
+	}
+	return &QuantileSketch{
+		compression: 1 / epsilon,
+		min:         math.Inf(1),
+		max:         math.Inf(-1),
+	}
+}
+
+// Count returns the number of values added.
+func (s *QuantileSketch) Count() uint64 {
+	return uint64(s.count)
+}
+
+// Add adds value to the stream. NaNs are ignored.
+func (s *QuantileSketch) Add(value float64) {
+	if math.IsNaN(value) {
+		return
+	}
+	s.add(centroid{value, 1})
+	s.min = math.Min(s.min, value)
+	s.max = math.Max(s.max, value)
+}
+
+func (s *QuantileSketch) add(c centroid) {
+	s.buffer = append(s.buffer, c)
+	s.count += c.count
+	if float64(len(s.buffer)) >= 5*s.compression {
+		s.compress()
+	}
+}
+
+// Merge adds the values summarized by other to the stream of
+// QuantileSketch, which then estimates the quantiles of both streams.
+func (s *QuantileSketch) Merge(other *QuantileSketch) {
+	for _, c := range other.centroids {
+		s.add(c)
+	}
+	for _, c := range other.buffer {
+		s.add(c)
+	}
+	s.min = math.Min(s.min, other.min)
+	s.max = math.Max(s.max, other.max)
+}
+
+// Quantile returns the estimated q-quantile of the stream, e.g. 0.5 for the
+// median, q being clamped to the [0, 1] range. Returns NaN if the stream is
+// empty.
+func (s *QuantileSketch) Quantile(q float64) float64 {
+	s.compress()
+	if s.count == 0 {
+		return math.NaN()
+	}
+	if q <= 0 {
+		return s.min
+	}
+	if q >= 1 {
+		return s.max
+	}
+
+	// Each centroid stands for the rank at its center, and ranks in between
+	// are interpolated, using min and max beyond the outer centroids.
+	target := q * s.count
+	cumulative := 0.0
+	prevMean, prevRank := s.min, 0.0
+	for _, c := range s.centroids {
+		rank := cumulative + c.count/2
+		if target < rank {
+			return prevMean + (c.mean-prevMean)*(target-prevRank)/(rank-prevRank)
+		}
+		prevMean, prevRank = c.mean, rank
+		cumulative += c.count
+	}
+	return prevMean + (s.max-prevMean)*(target-prevRank)/(s.count-prevRank)
+}
+
+// scale maps a quantile to the k1 scale of the t-digest, where a centroid
+// may span at most 1. Its slope is steep near 0 and 1, which keeps the
+// centroids at the tails small.
+func (s *QuantileSketch) scale(q float64) float64 {
+	return s.compression / (2 * math.Pi) * math.Asin(2*q-1)
+}
+
+// compress merges the buffered centroids into the sorted ones.
+func (s *QuantileSketch) compress() {
+	if len(s.buffer) == 0 {
+		return
+	}
+	all := append(s.centroids, s.buffer...)
+	s.buffer = s.buffer[:0]
+	sort.Slice(all, func(i, j int) bool { return all[i].mean < all[j].mean })
+
+	merged := all[:0]
+	current := all[0]
+	before := 0.0 // count of the centroids before current
+	kLeft := s.scale(0)
+	for _, c := range all[1:] {
+		if s.scale((before+current.count+c.count)/s.count)-kLeft <= 1 {
+			current.count += c.count
+			current.mean += (c.mean - current.mean) * c.count / current.count
+			continue
+		}
+		merged = append(merged, current)
+		before += current.count
+		kLeft = s.scale(before / s.count)
+		current = c
+	}
+	s.centroids = append(merged, current)
+}
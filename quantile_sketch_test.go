@@ -0,0 +1,97 @@
+package gods
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// checkQuantiles checks that the ranks of the estimated quantiles are
+// within epsilon of the exact ones in sorted.
+func checkQuantiles(t *testing.T, s *QuantileSketch, sorted []float64, epsilon float64) {
+	t.Helper()
+	n := float64(len(sorted))
+	for _, q := range []float64{0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.95, 0.99, 0.999} {
+		estimate := s.Quantile(q)
+		lo := float64(sort.SearchFloat64s(sorted, estimate)) / n
+		hi := float64(sort.Search(len(sorted), func(i int) bool { return sorted[i] > estimate })) / n
+		if q < lo-epsilon || q > hi+epsilon {
+			t.Errorf("Quantile(%v) = %v has rank [%v, %v], expected within %v", q, estimate, lo, hi, epsilon)
+		}
+	}
+}
+
+func TestQuantileSketch(t *testing.T) {
+	const epsilon = 0.01
+	r := rand.New(rand.NewSource(1))
+	distributions := []struct {
+		name string
+		next func() float64
+	}{
+		{"uniform", r.Float64},
+		{"normal", r.NormFloat64},
+		{"exponential", r.ExpFloat64},
+	}
+	for _, d := range distributions {
+		s := NewQuantileSketch(epsilon)
+		values := make([]float64, 100000)
+		for i := range values {
+			values[i] = d.next()
+			s.Add(values[i])
+		}
+		sort.Float64s(values)
+		t.Run(d.name, func(t *testing.T) {
+			checkQuantiles(t, s, values, epsilon)
+			if s.Quantile(0) != values[0] || s.Quantile(1) != values[len(values)-1] {
+				t.Error("expected extreme quantiles to be exact")
+			}
+			if len(s.centroids) > int(10/epsilon) {
+				t.Errorf("expected O(1/epsilon) centroids, got %d", len(s.centroids))
+			}
+		})
+	}
+}
+
+func TestQuantileSketchMerge(t *testing.T) {
+	const epsilon = 0.01
+	r := rand.New(rand.NewSource(2))
+	merged := NewQuantileSketch(epsilon)
+	var values []float64
+	for part := 0; part < 4; part++ {
+		s := NewQuantileSketch(epsilon)
+		for i := 0; i < 25000; i++ {
+			// Shift each part so that they do not overlap much.
+			v := r.NormFloat64() + float64(part)*2
+			values = append(values, v)
+			s.Add(v)
+		}
+		merged.Merge(s)
+	}
+	sort.Float64s(values)
+	if merged.Count() != uint64(len(values)) {
+		t.Fatalf("expected count %d, got %d", len(values), merged.Count())
+	}
+	checkQuantiles(t, merged, values, epsilon)
+}
+
+func TestQuantileSketchSmall(t *testing.T) {
+	s := NewQuantileSketch(0.01)
+	if !math.IsNaN(s.Quantile(0.5)) {
+		t.Error("expected NaN for an empty sketch")
+	}
+	s.Add(math.NaN())
+	s.Add(3)
+	if s.Count() != 1 || s.Quantile(0.5) != 3 {
+		t.Errorf("expected the single value as median, got %v", s.Quantile(0.5))
+	}
+	for _, v := range []float64{1, 2, 4, 5} {
+		s.Add(v)
+	}
+	if m := s.Quantile(0.5); m != 3 {
+		t.Errorf("expected median 3, got %v", m)
+	}
+	if !panics(func() { NewQuantileSketch(0) }) {
+		t.Error("expected epsilon 0 to panic")
+	}
+}
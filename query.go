@@ -0,0 +1,44 @@
+package gods
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Query navigates nested Maps and Slices along a dotted path, e.g.
+// "users.0.name", and returns the value found at its end. Each segment is
+// looked up as a string key in a Map, or parsed as an index into a Slice.
+// Raw []interface{} and map[string]interface{} values, as produced by
+// encoding/json, are navigated alike. An empty path returns root itself.
+// Returns (nil, false) if any segment is missing.
+func Query(root Container, path string) (interface{}, bool) {
+	var current interface{} = root
+	if path == "" {
+		return current, true
+	}
+	for _, segment := range strings.Split(path, ".") {
+		var ok bool
+		switch c := current.(type) {
+		case Map:
+			current, ok = c.Get(segment)
+		case map[string]interface{}:
+			current, ok = c[segment]
+		case Slice:
+			current, ok = queryIndex(c.Raw(), segment)
+		case []interface{}:
+			current, ok = queryIndex(c, segment)
+		}
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func queryIndex(raw []interface{}, segment string) (interface{}, bool) {
+	i, err := strconv.Atoi(segment)
+	if err != nil || i < 0 || i >= len(raw) {
+		return nil, false
+	}
+	return raw[i], true
+}
@@ -0,0 +1,52 @@
+package gods
+
+import "testing"
+
+func TestQuery(t *testing.T) {
+	root := newTestMap(
+		"users", NewArraySlice(
+			newTestMap("name", "ann", "tags", NewArraySlice("a", "b")),
+			newTestMap("name", "bob", "address", map[string]interface{}{
+				"city":  "paris",
+				"lines": []interface{}{"1 rue", "2e"},
+			}),
+		),
+		"count", 2,
+	)
+
+	tests := []struct {
+		path string
+		want interface{}
+		ok   bool
+	}{
+		{"count", 2, true},
+		{"users.0.name", "ann", true},
+		{"users.1.name", "bob", true},
+		{"users.0.tags.1", "b", true},
+		{"users.1.address.city", "paris", true},
+		{"users.1.address.lines.0", "1 rue", true},
+		{"missing", nil, false},
+		{"users.0.missing", nil, false},
+		{"users.2.name", nil, false},
+		{"users.-1.name", nil, false},
+		{"users.first.name", nil, false},
+		{"users.0.tags.2", nil, false},
+		{"users.1.address.lines.x", nil, false},
+		{"count.0", nil, false},
+		{"users.0.name.x", nil, false},
+		{"users..name", nil, false},
+	}
+	for _, tt := range tests {
+		got, ok := Query(root, tt.path)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Query(%q) = (%v, %v), expected (%v, %v)", tt.path, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	if got, ok := Query(root, ""); got != root || !ok {
+		t.Errorf("expected empty path to return root, got (%v, %v)", got, ok)
+	}
+	if got, ok := Query(NewArraySlice(10, 20), "1"); got != 20 || !ok {
+		t.Errorf("expected Slice root to be indexed, got (%v, %v)", got, ok)
+	}
+}
@@ -0,0 +1,118 @@
+package gods
+
+// RoundRobinQueue is a composite first-in-first-out Container made of
+// several lanes. Elements are pushed to a given lane, and Pop cycles
+// through the lanes, skipping the empty ones, so that no lane starves.
+//
+// With weighted round-robin, a lane of weight w serves up to w consecutive
+// Pops before the next lane gets its turn.
+type RoundRobinQueue struct {
+	lanes   [][]interface{}
+	weights []int
+	current int // lane whose turn it is
+	served  int // Pops served by the current lane during its turn
+	size    int
+}
+
+// NewRoundRobinQueue creates an empty RoundRobinQueue with the given number
+// of lanes, each with weight 1. Panics if lanes is not positive.
+func NewRoundRobinQueue(lanes int) *RoundRobinQueue {
+	if lanes <= 0 {
+		panic("gods: RoundRobinQueue needs at least one lane")
+	}
+	weights := make([]int, lanes)
+	for i := range weights {
+		weights[i] = 1
+	}
+	return NewWeightedRoundRobinQueue(weights...)
+}
+
+// NewWeightedRoundRobinQueue creates an empty RoundRobinQueue with one lane
+// per weight. Panics if there is no weight or a weight is not positive.
+func NewWeightedRoundRobinQueue(weights ...int) *RoundRobinQueue {
+	if len(weights) == 0 {
+		panic("gods: RoundRobinQueue needs at least one lane")
+	}
+	for _, w := range weights {
+		if w <= 0 {
+			panic("gods: RoundRobinQueue lane weight must be positive")
+		}
+	}
+	return &RoundRobinQueue{
+		lanes:   make([][]interface{}, len(weights)),
+		weights: append([]int(nil), weights...),
+	}
+}
+
+// Empty indicates if all the lanes of RoundRobinQueue are empty.
+func (q *RoundRobinQueue) Empty() bool {
+	return q.size == 0
+}
+
+// Size retrieves the total number of elements in all the lanes.
+func (q *RoundRobinQueue) Size() int {
+	return q.size
+}
+
+// Lanes retrieves the number of lanes of RoundRobinQueue.
+func (q *RoundRobinQueue) Lanes() int {
+	return len(q.lanes)
+}
+
+// LaneSize retrieves the number of elements in the given lane.
+func (q *RoundRobinQueue) LaneSize(lane int) int {
+	return len(q.lanes[lane])
+}
+
+// Clear resets RoundRobinQueue, all the lanes will be empty.
+func (q *RoundRobinQueue) Clear() {
+	for i := range q.lanes {
+		q.lanes[i] = nil
+	}
+	q.current, q.served, q.size = 0, 0, 0
+}
+
+// PushTo appends an element to the end of the given lane. Panics if lane is
+// out of range.
+func (q *RoundRobinQueue) PushTo(lane int, v interface{}) {
+	q.lanes[lane] = append(q.lanes[lane], v)
+	q.size++
+}
+
+// Peek inspects the element the next Pop returns without modifying the
+// RoundRobinQueue. Returns (nil, false) if the RoundRobinQueue is empty.
+func (q *RoundRobinQueue) Peek() (interface{}, bool) {
+	if q.size == 0 {
+		return nil, false
+	}
+	for i := 0; ; i++ {
+		if lane := q.lanes[(q.current+i)%len(q.lanes)]; len(lane) > 0 {
+			return lane[0], true
+		}
+	}
+}
+
+// Pop ejects the start element of the lane whose turn it is and removes it.
+// Empty lanes lose their turn. Returns nil if the RoundRobinQueue is empty.
+func (q *RoundRobinQueue) Pop() interface{} {
+	if q.size == 0 {
+		return nil
+	}
+	for len(q.lanes[q.current]) == 0 {
+		q.nextLane()
+	}
+	lane := q.lanes[q.current]
+	v := lane[0]
+	lane[0] = nil
+	q.lanes[q.current] = lane[1:]
+	q.size--
+	if q.served++; q.served == q.weights[q.current] {
+		q.nextLane()
+	}
+	return v
+}
+
+func (q *RoundRobinQueue) nextLane() {
+	q.current = (q.current + 1) % len(q.lanes)
+	q.served = 0
+}
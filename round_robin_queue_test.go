@@ -0,0 +1,70 @@
+package gods
+
+import (
+	"fmt"
+	"testing"
+)
+
+func drainRoundRobin(t *testing.T, q *RoundRobinQueue) []interface{} {
+	t.Helper()
+	var result []interface{}
+	for !q.Empty() {
+		peeked, _ := q.Peek()
+		v := q.Pop()
+		if v != peeked {
+			t.Fatalf("expected Pop to return peeked %v, got %v", peeked, v)
+		}
+		result = append(result, v)
+	}
+	return result
+}
+
+func TestRoundRobinQueue(t *testing.T) {
+	q := NewRoundRobinQueue(3)
+	if !q.Empty() || q.Pop() != nil || q.Lanes() != 3 {
+		t.Fatal("expected new queue with 3 empty lanes")
+	}
+	for _, v := range []string{"a1", "a2", "a3", "a4"} {
+		q.PushTo(0, v)
+	}
+	q.PushTo(1, "b1")
+	q.PushTo(2, "c1")
+	q.PushTo(2, "c2")
+	if q.Size() != 7 || q.LaneSize(2) != 2 {
+		t.Fatalf("expected size 7, got %d", q.Size())
+	}
+
+	got := fmt.Sprint(drainRoundRobin(t, q))
+	if want := "[a1 b1 c1 a2 c2 a3 a4]"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	q.PushTo(1, "b2")
+	q.PushTo(0, "a5")
+	q.Clear()
+	if !q.Empty() || q.LaneSize(0) != 0 {
+		t.Error("expected queue to be empty after Clear")
+	}
+}
+
+func TestWeightedRoundRobinQueue(t *testing.T) {
+	q := NewWeightedRoundRobinQueue(2, 1)
+	for _, v := range []string{"a1", "a2", "a3", "a4", "a5"} {
+		q.PushTo(0, v)
+	}
+	for _, v := range []string{"b1", "b2"} {
+		q.PushTo(1, v)
+	}
+
+	got := fmt.Sprint(drainRoundRobin(t, q))
+	if want := "[a1 a2 b1 a3 a4 b2 a5]"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for a non positive weight")
+		}
+	}()
+	NewWeightedRoundRobinQueue(1, 0)
+}
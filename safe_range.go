@@ -0,0 +1,20 @@
+package gods
+
+// RangeWithKeySafe iterates r like RangeWithKey, but over a snapshot of its
+// keys taken beforehand, so fn may add or delete members of the underlying
+// Container. Every key present when RangeWithKeySafe is called is visited
+// exactly once, even if it was deleted in the meantime, and keys added
+// during the iteration are not visited.
+// Stop iterating if the KeyRangerFunc returns false.
+func RangeWithKeySafe(r KeyRanger, fn KeyRangerFunc) {
+	var keys []interface{}
+	r.RangeWithKey(func(key interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+	for _, key := range keys {
+		if !fn(key) {
+			return
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package gods
+
+import "testing"
+
+// failFastSet panics when it is modified while being ranged.
+type failFastSet struct {
+	members map[interface{}]struct{}
+	ranging bool
+}
+
+func (s *failFastSet) RangeWithKey(fn KeyRangerFunc) {
+	s.ranging = true
+	defer func() { s.ranging = false }()
+	for k := range s.members {
+		if !fn(k) {
+			return
+		}
+	}
+}
+
+func (s *failFastSet) Delete(key interface{}) {
+	if s.ranging {
+		panic("concurrent modification")
+	}
+	delete(s.members, key)
+}
+
+func (s *failFastSet) Add(key interface{}) {
+	if s.ranging {
+		panic("concurrent modification")
+	}
+	s.members[key] = struct{}{}
+}
+
+func TestRangeWithKeySafe(t *testing.T) {
+	s := &failFastSet{members: map[interface{}]struct{}{}}
+	for i := 0; i < 10; i++ {
+		s.Add(i)
+	}
+
+	visits := make(map[interface{}]int)
+	RangeWithKeySafe(s, func(key interface{}) bool {
+		visits[key]++
+		s.Delete(key)
+		// Delete another member ahead of the iteration.
+		s.Delete((key.(int) + 1) % 10)
+		s.Add(key.(int) + 100)
+		return true
+	})
+
+	if len(visits) != 10 {
+		t.Fatalf("expected the 10 original members to be visited, got %v", visits)
+	}
+	for key, n := range visits {
+		if key.(int) >= 10 || n != 1 {
+			t.Errorf("expected %v to be an original member visited once, got %d", key, n)
+		}
+	}
+	if len(s.members) != 10 {
+		t.Errorf("expected only the added members left, got %v", s.members)
+	}
+
+	n := 0
+	RangeWithKeySafe(s, func(interface{}) bool {
+		n++
+		return false
+	})
+	if n != 1 {
+		t.Errorf("expected early stop after the first key, got %d visits", n)
+	}
+}
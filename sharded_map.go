@@ -0,0 +1,277 @@
+package gods
+
+import "sync"
+
+// DefaultShards is the number of shards of a ShardedMap created by
+// NewShardedMap.
+const DefaultShards = 16
+
+// ShardedMap is a Map safe for concurrent use, which partitions its keys
+// across shards each guarded by its own sync.RWMutex, so that writers of
+// keys in different shards do not contend. Keys must be comparable, see
+// MustBeComparable.
+type ShardedMap struct {
+	shards    []mapShard
+	nilPolicy NilPolicy
+}
+
+type mapShard struct {
+	sync.RWMutex
+	m map[interface{}]interface{}
+}
+
+var (
+	_ Map      = (*ShardedMap)(nil)
+	_ KVRanger = (*ShardedMap)(nil)
+)
+
+// NewShardedMap creates an empty ShardedMap with DefaultShards shards.
+func NewShardedMap() *ShardedMap {
+	return NewShardedMapWithShards(DefaultShards)
+}
+
+// NewShardedMapWithShards creates an empty ShardedMap with the given number
+// of shards. Panics if shards is not positive.
+func NewShardedMapWithShards(shards int) *ShardedMap {
+	return NewShardedMapWithNilPolicy(shards, AllowNil)
+}
+
+// NewShardedMapWithNilPolicy creates an empty ShardedMap with the given
+// number of shards, handling nil keys and values according to policy.
+// Panics if shards is not positive.
+func NewShardedMapWithNilPolicy(shards int, policy NilPolicy) *ShardedMap {
+	if shards <= 0 {
+		panic("gods: ShardedMap needs at least one shard")
+	}
+	m := &ShardedMap{shards: make([]mapShard, shards), nilPolicy: policy}
+	for i := range m.shards {
+		m.shards[i].m = make(map[interface{}]interface{})
+	}
+	return m
+}
+
+// Shards retrieves the number of shards of ShardedMap.
+func (m *ShardedMap) Shards() int {
+	return len(m.shards)
+}
+
+func (m *ShardedMap) shard(key interface{}) *mapShard {
+	return &m.shards[hashKey(key)%uint64(len(m.shards))]
+}
+
+// empty creates an empty ShardedMap with as many shards and the same
+// NilPolicy as ShardedMap.
+func (m *ShardedMap) empty() *ShardedMap {
+	return NewShardedMapWithNilPolicy(len(m.shards), m.nilPolicy)
+}
+
+// Empty indicates if the ShardedMap is empty.
+func (m *ShardedMap) Empty() bool {
+	return m.Size() == 0
+}
+
+// Size retrieves the number of (key,value) pairs of ShardedMap. Shards are
+// counted one after the other, so concurrent changes may or may not be
+// accounted for.
+func (m *ShardedMap) Size() int {
+	size := 0
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.RLock()
+		size += len(s.m)
+		s.RUnlock()
+	}
+	return size
+}
+
+// Clear resets ShardedMap, it will be empty with size 0.
+func (m *ShardedMap) Clear() {
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.Lock()
+		s.m = make(map[interface{}]interface{})
+		s.Unlock()
+	}
+}
+
+// Clone returns an independent copy of ShardedMap with as many shards and
+// the same NilPolicy. Shards are copied one after the other, so concurrent
+// changes may or may not be copied.
+func (m *ShardedMap) Clone() Map {
+	clone := m.empty()
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.RLock()
+		for k, v := range s.m {
+			clone.shards[i].m[k] = v
+		}
+		s.RUnlock()
+	}
+	return clone
+}
+
+// Add adds a new (key,value) pair to the ShardedMap, mapping the new key to
+// its new value, unless the NilPolicy ignores it.
+func (m *ShardedMap) Add(key, value interface{}) Map {
+	if !m.nilPolicy.admit(key, value) {
+		return m
+	}
+	s := m.shard(key)
+	s.Lock()
+	s.m[key] = value
+	s.Unlock()
+	return m
+}
+
+// Get finds the value (if any) that is bound to a given key.
+func (m *ShardedMap) Get(key interface{}) (interface{}, bool) {
+	s := m.shard(key)
+	s.RLock()
+	v, ok := s.m[key]
+	s.RUnlock()
+	return v, ok
+}
+
+// Has checks whether the key is in the ShardedMap.
+func (m *ShardedMap) Has(key interface{}) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Delete removes a (key,value) pair from the ShardedMap, unmapping a given
+// key from its value.
+func (m *ShardedMap) Delete(key interface{}) {
+	s := m.shard(key)
+	s.Lock()
+	delete(s.m, key)
+	s.Unlock()
+}
+
+// DeleteAll removes the (key,value) pairs of all the keys from the
+// ShardedMap, and returns the number of keys which were present.
+func (m *ShardedMap) DeleteAll(keys ...interface{}) int {
+	removed := 0
+	for _, key := range keys {
+		s := m.shard(key)
+		s.Lock()
+		if _, ok := s.m[key]; ok {
+			delete(s.m, key)
+			removed++
+		}
+		s.Unlock()
+	}
+	return removed
+}
+
+// ComputeIfAbsent returns the value bound to key. If key is not in the
+// ShardedMap, supplier is called once and its result is bound to key,
+// unless the NilPolicy ignores it, and returned. supplier is called with
+// the shard of key locked, so it must not access the ShardedMap.
+func (m *ShardedMap) ComputeIfAbsent(key interface{}, supplier func() interface{}) interface{} {
+	s := m.shard(key)
+	s.Lock()
+	defer s.Unlock()
+	v, ok := s.m[key]
+	if !ok {
+		v = supplier()
+		if m.nilPolicy.admit(key, v) {
+			s.m[key] = v
+		}
+	}
+	return v
+}
+
+// ComputeIfPresent rebinds key to the result of remap called with its
+// current value, unless the NilPolicy ignores the result. It does nothing
+// if key is not in the ShardedMap. remap is called with the shard of key
+// locked, so it must not access the ShardedMap.
+func (m *ShardedMap) ComputeIfPresent(key interface{}, remap func(old interface{}) interface{}) {
+	s := m.shard(key)
+	s.Lock()
+	defer s.Unlock()
+	if v, ok := s.m[key]; ok {
+		if v = remap(v); m.nilPolicy.admitValue(v) {
+			s.m[key] = v
+		}
+	}
+}
+
+// RangeKV iterates over ShardedMap (key,value) pairs, one shard after the
+// other. Each shard is copied before being visited, so fn may access the
+// ShardedMap. Stop iterating if the KVRangerFunc returns false.
+func (m *ShardedMap) RangeKV(fn KVRangerFunc) {
+	var keys, values []interface{}
+	for i := range m.shards {
+		s := &m.shards[i]
+		keys, values = keys[:0], values[:0]
+		s.RLock()
+		for k, v := range s.m {
+			keys = append(keys, k)
+			values = append(values, v)
+		}
+		s.RUnlock()
+		for j, k := range keys {
+			if !fn(k, values[j]) {
+				return
+			}
+		}
+	}
+}
+
+// Invert returns a new ShardedMap mapping each value to its key. If several
+// keys share the same value, the last one visited wins. Panics if a value
+// is not comparable, see MustBeComparable.
+func (m *ShardedMap) Invert() Map {
+	inverted := m.empty()
+	m.RangeKV(func(key, value interface{}) bool {
+		MustBeComparable(value)
+		inverted.Add(value, key)
+		return true
+	})
+	return inverted
+}
+
+// FilterKeys returns a new ShardedMap with the (key,value) pairs whose key
+// satisfies predicate. The ShardedMap is not modified.
+func (m *ShardedMap) FilterKeys(predicate func(key interface{}) bool) Map {
+	return m.FilterEntries(func(key, _ interface{}) bool { return predicate(key) })
+}
+
+// FilterValues returns a new ShardedMap with the (key,value) pairs whose
+// value satisfies predicate. The ShardedMap is not modified.
+func (m *ShardedMap) FilterValues(predicate func(value interface{}) bool) Map {
+	return m.FilterEntries(func(_, value interface{}) bool { return predicate(value) })
+}
+
+// FilterEntries returns a new ShardedMap with the (key,value) pairs
+// satisfying predicate. The ShardedMap is not modified.
+func (m *ShardedMap) FilterEntries(predicate func(key, value interface{}) bool) Map {
+	filtered := m.empty()
+	m.RangeKV(func(key, value interface{}) bool {
+		if predicate(key, value) {
+			filtered.Add(key, value)
+		}
+		return true
+	})
+	return filtered
+}
+
+// MapValues returns a new ShardedMap binding each key to its value
+// projected by transform. The ShardedMap is not modified.
+func (m *ShardedMap) MapValues(transform func(value interface{}) interface{}) Map {
+	return m.MapEntries(func(key, value interface{}) (interface{}, interface{}) {
+		return key, transform(value)
+	})
+}
+
+// MapEntries returns a new ShardedMap with each (key,value) pair projected
+// by transform. If several pairs are projected to the same key, the last
+// one visited wins. The ShardedMap is not modified.
+func (m *ShardedMap) MapEntries(transform func(key, value interface{}) (interface{}, interface{})) Map {
+	mapped := m.empty()
+	m.RangeKV(func(key, value interface{}) bool {
+		mapped.Add(transform(key, value))
+		return true
+	})
+	return mapped
+}
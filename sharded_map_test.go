@@ -0,0 +1,274 @@
+package gods
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestShardedMap(t *testing.T) {
+	m := NewShardedMap()
+	if !m.Empty() || m.Shards() != DefaultShards {
+		t.Fatal("expected an empty map with the default shards")
+	}
+	for i := 0; i < 1000; i++ {
+		m.Add(i, i*i)
+	}
+	if m.Size() != 1000 {
+		t.Fatalf("expected size 1000, got %d", m.Size())
+	}
+	for i := range m.shards {
+		if len(m.shards[i].m) == 0 {
+			t.Errorf("expected keys in shard %d", i)
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		if v, ok := m.Get(i); !ok || v != i*i {
+			t.Fatalf("expected %d bound to %d, got %v", i, i*i, v)
+		}
+	}
+	m.Delete(0)
+	if m.Has(0) || m.Size() != 999 {
+		t.Error("expected 0 to be deleted")
+	}
+
+	if v := m.ComputeIfAbsent(1, func() interface{} { return -1 }); v != 1 {
+		t.Errorf("expected present value 1, got %v", v)
+	}
+	if v := m.ComputeIfAbsent(0, func() interface{} { return -1 }); v != -1 {
+		t.Errorf("expected supplied value -1, got %v", v)
+	}
+	m.ComputeIfPresent(2, func(old interface{}) interface{} { return old.(int) + 1 })
+	m.ComputeIfPresent(-2, func(old interface{}) interface{} { return 0 })
+	if v, _ := m.Get(2); v != 5 || m.Has(-2) {
+		t.Errorf("expected 2 remapped to 5, got %v", v)
+	}
+
+	count := 0
+	m.RangeKV(func(key, value interface{}) bool {
+		count++
+		return count < 10
+	})
+	if count != 10 {
+		t.Errorf("expected RangeKV to stop after 10 pairs, got %d", count)
+	}
+
+	m.Clear()
+	if !m.Empty() {
+		t.Error("expected map to be empty after Clear")
+	}
+}
+
+func TestShardedMapKeys(t *testing.T) {
+	type point struct{ x, y int }
+	p := &point{1, 2}
+	m := NewShardedMapWithShards(64)
+	m.Add(0.0, "zero").Add(p, "p").Add(point{1, 2}, "point").Add(nil, "nil")
+
+	if v, _ := m.Get(math.Copysign(0, -1)); v != "zero" {
+		t.Errorf("expected -0 to find 0, got %v", v)
+	}
+	p.x = 3
+	if v, _ := m.Get(p); v != "p" {
+		t.Errorf("expected a mutated pointer key to be found, got %v", v)
+	}
+	if v, _ := m.Get(point{1, 2}); v != "point" {
+		t.Errorf("expected an equal struct key to be found, got %v", v)
+	}
+	if v, _ := m.Get(nil); v != "nil" {
+		t.Errorf("expected nil key to be found, got %v", v)
+	}
+}
+
+func TestShardedMapConcurrent(t *testing.T) {
+	m := NewShardedMapWithShards(4)
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				m.Add(w*500+i, w)
+				m.ComputeIfPresent(w*500+i, func(old interface{}) interface{} { return old.(int) + 1 })
+				m.Get(i)
+			}
+		}(w)
+	}
+	wg.Wait()
+	if m.Size() != 4000 {
+		t.Fatalf("expected size 4000, got %d", m.Size())
+	}
+	for i := 0; i < 4000; i++ {
+		if v, _ := m.Get(i); v != i/500+1 {
+			t.Fatalf("expected %d bound to %d, got %v", i, i/500+1, v)
+		}
+	}
+}
+
+func TestShardedMapFilter(t *testing.T) {
+	m := NewShardedMap()
+	m.Add("user:ann", 30).Add("user:bob", 12).Add("group:dev", 5)
+
+	users := m.FilterKeys(func(key interface{}) bool {
+		return strings.HasPrefix(key.(string), "user:")
+	})
+	if users.Size() != 2 || !users.Has("user:ann") || !users.Has("user:bob") {
+		t.Errorf("expected 2 users, got %d", users.Size())
+	}
+	large := m.FilterValues(func(value interface{}) bool { return value.(int) >= 12 })
+	if large.Size() != 2 || large.Has("group:dev") {
+		t.Errorf("expected 2 values >= 12, got %d", large.Size())
+	}
+	both := m.FilterEntries(func(key, value interface{}) bool {
+		return strings.HasPrefix(key.(string), "user:") && value.(int) < 20
+	})
+	if both.Size() != 1 || !both.Has("user:bob") {
+		t.Errorf("expected only user:bob, got %d pairs", both.Size())
+	}
+	if m.Size() != 3 {
+		t.Errorf("expected source map to be unchanged, got size %d", m.Size())
+	}
+}
+
+func TestShardedMapMap(t *testing.T) {
+	m := NewShardedMap()
+	for i := 1; i <= 4; i++ {
+		m.Add(strconv.Itoa(i), i)
+	}
+
+	doubled := m.MapValues(func(value interface{}) interface{} { return value.(int) * 2 })
+	for i := 1; i <= 4; i++ {
+		if v, _ := doubled.Get(strconv.Itoa(i)); v != i*2 {
+			t.Errorf("expected %d doubled, got %v", i, v)
+		}
+	}
+	if v, _ := m.Get("1"); v != 1 {
+		t.Errorf("expected source map to be unchanged, got %v", v)
+	}
+
+	parity := m.MapEntries(func(key, value interface{}) (interface{}, interface{}) {
+		return value.(int) % 2, key
+	})
+	if parity.Size() != 2 {
+		t.Fatalf("expected colliding keys to collapse into 2, got %d", parity.Size())
+	}
+	if v, _ := parity.Get(0); v != "2" && v != "4" {
+		t.Errorf("expected an even key, got %v", v)
+	}
+
+	inverted := m.Invert()
+	if v, _ := inverted.Get(3); v != "3" || inverted.Size() != 4 {
+		t.Errorf("expected 3 inverted to \"3\", got %v", v)
+	}
+}
+
+// mutexMap is a Go map guarded by a single mutex, the baseline ShardedMap
+// is benchmarked against.
+type mutexMap struct {
+	sync.RWMutex
+	m map[interface{}]interface{}
+}
+
+func (m *mutexMap) Add(key, value interface{}) {
+	m.Lock()
+	m.m[key] = value
+	m.Unlock()
+}
+
+func BenchmarkShardedMapConcurrentAdd(b *testing.B) {
+	m := NewShardedMap()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			m.Add(i%1024, i)
+		}
+	})
+}
+
+func BenchmarkMutexMapConcurrentAdd(b *testing.B) {
+	m := &mutexMap{m: make(map[interface{}]interface{})}
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			m.Add(i%1024, i)
+		}
+	})
+}
+
+func TestShardedMapDeleteAll(t *testing.T) {
+	m := NewShardedMap()
+	for i := 0; i < 10; i++ {
+		m.Add(i, i)
+	}
+	if n := m.DeleteAll(1, 3, 3, 42, 5, -1); n != 3 {
+		t.Errorf("expected 3 keys removed, got %d", n)
+	}
+	if m.Size() != 7 || m.Has(3) {
+		t.Errorf("expected 7 keys left, got %d", m.Size())
+	}
+	if n := m.DeleteAll(); n != 0 {
+		t.Errorf("expected nothing removed, got %d", n)
+	}
+}
+
+func TestShardedMapClone(t *testing.T) {
+	m := NewShardedMapWithNilPolicy(4, RejectNilKey)
+	for i := 0; i < 20; i++ {
+		m.Add(i, i*i)
+	}
+	clone := m.Clone().(*ShardedMap)
+	if clone.Shards() != 4 || clone.nilPolicy != RejectNilKey {
+		t.Errorf("expected 4 shards and the NilPolicy to be kept, got %d and %v", clone.Shards(), clone.nilPolicy)
+	}
+	clone.Add(0, "rebound")
+	clone.DeleteAll(1, 2, 3)
+	clone.Add(20, 400)
+
+	if m.Size() != 20 || m.Has(20) {
+		t.Errorf("expected the original to keep 20 pairs, got %d", m.Size())
+	}
+	for i := 0; i < 20; i++ {
+		if v, ok := m.Get(i); !ok || v != i*i {
+			t.Errorf("expected %d bound to %d in the original, got %v", i, i*i, v)
+		}
+	}
+	if v, _ := clone.Get(0); v != "rebound" || clone.Size() != 18 {
+		t.Errorf("expected the clone to be mutated, got %v with size %d", v, clone.Size())
+	}
+}
+
+// floatKey is a struct key holding a float, whose -0 and 0 are ==.
+type floatKey struct {
+	name string
+	x    float64
+	c    complex64
+}
+
+func TestShardedMapSignedZeroKeys(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+	tests := []struct {
+		name     string
+		pos, neg interface{}
+	}{
+		{"float32", float32(0), float32(negZero)},
+		{"float64", 0.0, negZero},
+		{"struct", floatKey{"p", 0, 0}, floatKey{"p", negZero, complex(float32(negZero), 0)}},
+		{"interface field", struct{ v interface{} }{float32(0)}, struct{ v interface{} }{float32(negZero)}},
+		{"array", [2]float64{1, 0}, [2]float64{1, negZero}},
+	}
+	for _, tt := range tests {
+		if tt.pos != tt.neg {
+			t.Fatalf("%s: expected the keys to be ==", tt.name)
+		}
+		// A shard per key maximizes the chance of a mismatch.
+		m := NewShardedMapWithShards(1 << 10)
+		m.Add(tt.pos, 1)
+		if v, ok := m.Get(tt.neg); !ok || v != 1 {
+			t.Errorf("%s: expected -0 to find the 0 key, got (%v, %v)", tt.name, v, ok)
+		}
+		m.Add(tt.neg, 2)
+		if m.Size() != 1 {
+			t.Errorf("%s: expected -0 to rebind the 0 key, got size %d", tt.name, m.Size())
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package gods
+
+import "sort"
+
+// SortedRangeKV iterates r like RangeKV, but visits the keys in the order
+// given by cmp instead of the native order of r, which is handy for
+// deterministic output from hash based Containers. It takes a snapshot of
+// all the pairs and sorts them before iterating.
+// Stop iterating if the KVRangerFunc returns false.
+func SortedRangeKV(r KVRanger, cmp func(a, b interface{}) int, fn KVRangerFunc) {
+	var keys, values []interface{}
+	r.RangeKV(func(key, value interface{}) bool {
+		keys = append(keys, key)
+		values = append(values, value)
+		return true
+	})
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return cmp(keys[order[i]], keys[order[j]]) < 0
+	})
+	for _, i := range order {
+		if !fn(keys[i], values[i]) {
+			return
+		}
+	}
+}
+
+// RangeSorted iterates r like RangeWithKey, but visits the keys in the order
+// given by cmp, e.g. to enumerate the members of a hash based Set in sorted
+// order. It takes a snapshot of all the keys and sorts them before
+// iterating. Stop iterating if the KeyRangerFunc returns false.
+func RangeSorted(r KeyRanger, cmp func(a, b interface{}) int, fn KeyRangerFunc) {
+	var keys []interface{}
+	r.RangeWithKey(func(key interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+	sort.SliceStable(keys, func(i, j int) bool {
+		return cmp(keys[i], keys[j]) < 0
+	})
+	for _, key := range keys {
+		if !fn(key) {
+			return
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package gods
+
+import (
+	"fmt"
+	"testing"
+)
+
+// testKVRanger ranges over a Go map, in its random native order.
+type testKVRanger map[interface{}]interface{}
+
+func (m testKVRanger) RangeKV(fn KVRangerFunc) {
+	for k, v := range m {
+		if !fn(k, v) {
+			return
+		}
+	}
+}
+
+func TestSortedRangeKV(t *testing.T) {
+	m := testKVRanger{}
+	for _, k := range []int{5, 3, 9, 1, 7, 2, 8} {
+		m[k] = k * 10
+	}
+
+	for i := 0; i < 5; i++ {
+		var visited []interface{}
+		SortedRangeKV(m, intCompare, func(key, value interface{}) bool {
+			if value != key.(int)*10 {
+				t.Errorf("expected %v to be visited with its value, got %v", key, value)
+			}
+			visited = append(visited, key)
+			return true
+		})
+		if got := fmt.Sprint(visited); got != "[1 2 3 5 7 8 9]" {
+			t.Fatalf("expected keys in comparator order, got %s", got)
+		}
+	}
+
+	var visited []interface{}
+	SortedRangeKV(m, func(a, b interface{}) int { return intCompare(b, a) }, func(key, _ interface{}) bool {
+		visited = append(visited, key)
+		return len(visited) < 3
+	})
+	if got := fmt.Sprint(visited); got != "[9 8 7]" {
+		t.Errorf("expected early stop after 3 descending keys, got %s", got)
+	}
+}
+
+// testKeyRanger ranges over the keys of a Go map, in its random native order.
+type testKeyRanger map[interface{}]struct{}
+
+func (m testKeyRanger) RangeWithKey(fn KeyRangerFunc) {
+	for k := range m {
+		if !fn(k) {
+			return
+		}
+	}
+}
+
+func TestRangeSorted(t *testing.T) {
+	set := testKeyRanger{}
+	for _, k := range []string{"pear", "apple", "fig", "kiwi", "banana"} {
+		set[k] = struct{}{}
+	}
+	byString := func(a, b interface{}) int {
+		switch x, y := a.(string), b.(string); {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+
+	for i := 0; i < 5; i++ {
+		var visited []interface{}
+		RangeSorted(set, byString, func(key interface{}) bool {
+			visited = append(visited, key)
+			return true
+		})
+		if got := fmt.Sprint(visited); got != "[apple banana fig kiwi pear]" {
+			t.Fatalf("expected members in comparator order, got %s", got)
+		}
+	}
+
+	var visited []interface{}
+	RangeSorted(set, byString, func(key interface{}) bool {
+		visited = append(visited, key)
+		return key != "fig"
+	})
+	if got := fmt.Sprint(visited); got != "[apple banana fig]" {
+		t.Errorf("expected early stop at fig, got %s", got)
+	}
+}
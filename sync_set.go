@@ -0,0 +1,103 @@
+package gods
+
+import "sync"
+
+// SyncSet wraps a Set to make it safe for concurrent use, guarding it with
+// a sync.RWMutex held for reading by Empty, Size and Has. The wrapped Set
+// must not be accessed directly anymore.
+type SyncSet struct {
+	mu  sync.RWMutex
+	set Set
+}
+
+var _ Set = (*SyncSet)(nil)
+
+// NewSyncSet wraps set into a SyncSet.
+func NewSyncSet(set Set) *SyncSet {
+	return &SyncSet{set: set}
+}
+
+// Empty indicates if the SyncSet is empty.
+func (s *SyncSet) Empty() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Empty()
+}
+
+// Size retrieves the number of elements in the SyncSet.
+func (s *SyncSet) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Size()
+}
+
+// Clear resets SyncSet, it will be empty with size 0.
+func (s *SyncSet) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set.Clear()
+}
+
+// Add adds the elements to SyncSet, if they are not present already.
+func (s *SyncSet) Add(values ...interface{}) Set {
+	s.AddAllAtomic(values...)
+	return s
+}
+
+// AddAllAtomic adds the elements to SyncSet under a single lock, so that
+// no concurrent reader observes only some of them.
+func (s *SyncSet) AddAllAtomic(values ...interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set.Add(values...)
+}
+
+// TestAndAdd adds v to SyncSet and reports whether it was not present
+// already. Among concurrent calls with the same v, only one reports true.
+func (s *SyncSet) TestAndAdd(v interface{}) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.set.Has(v) {
+		return false
+	}
+	s.set.Add(v)
+	return true
+}
+
+// Has checks whether the element is in the SyncSet.
+func (s *SyncSet) Has(v interface{}) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Has(v)
+}
+
+// Delete removes the elements from SyncSet, if they are present.
+func (s *SyncSet) Delete(values ...interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set.Delete(values...)
+}
+
+// RemoveAll removes the elements from SyncSet under a single lock, and
+// returns the number of elements which were present.
+func (s *SyncSet) RemoveAll(values ...interface{}) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.set.RemoveAll(values...)
+}
+
+// Each calls fn with every element of SyncSet under a read lock, so fn must
+// not modify the SyncSet.
+func (s *SyncSet) Each(fn func(value interface{})) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.set.Each(fn)
+}
+
+// Count returns the number of elements of SyncSet satisfying predicate,
+// under a read lock.
+func (s *SyncSet) Count(predicate func(value interface{}) bool) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Count(predicate)
+}
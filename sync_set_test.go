@@ -0,0 +1,76 @@
+package gods
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSyncSet(t *testing.T) {
+	s := NewSyncSet(newTestSet())
+	if !s.Empty() {
+		t.Fatal("expected new set to be empty")
+	}
+	s.Add(1, 2).(*SyncSet).AddAllAtomic(2, 3)
+	if s.Size() != 3 || !s.Has(3) {
+		t.Errorf("expected 3 elements, got %d", s.Size())
+	}
+	if s.TestAndAdd(1) || !s.TestAndAdd(4) {
+		t.Error("expected TestAndAdd to report new elements only")
+	}
+	s.Delete(1, 2)
+	if s.Has(1) || s.Size() != 2 {
+		t.Error("expected 1 and 2 to be deleted")
+	}
+	s.Clear()
+	if !s.Empty() {
+		t.Error("expected set to be empty after Clear")
+	}
+}
+
+func TestSyncSetTestAndAddConcurrent(t *testing.T) {
+	s := NewSyncSet(newTestSet())
+	var winners int64
+	var wg sync.WaitGroup
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if s.TestAndAdd("same") {
+				atomic.AddInt64(&winners, 1)
+			}
+			s.Has("same")
+		}()
+	}
+	wg.Wait()
+	if winners != 1 {
+		t.Errorf("expected exactly one caller to add the value, got %d", winners)
+	}
+}
+
+func TestSyncSetRemoveAll(t *testing.T) {
+	s := NewSyncSet(newTestSet(1, 2, 3, 4))
+	if n := s.RemoveAll(2, 4, 6); n != 2 {
+		t.Errorf("expected 2 elements removed, got %d", n)
+	}
+	if s.Size() != 2 || s.Has(2) {
+		t.Errorf("expected 1 and 3 left, got size %d", s.Size())
+	}
+}
+
+func TestSyncSetEachAndCount(t *testing.T) {
+	s := NewSyncSet(newTestSet(1, 2, 3, 4, 5))
+	seen := make(map[interface{}]int)
+	s.Each(func(v interface{}) { seen[v]++ })
+	if len(seen) != 5 {
+		t.Errorf("expected 5 elements visited, got %v", seen)
+	}
+	for v, n := range seen {
+		if n != 1 {
+			t.Errorf("expected %v visited once, got %d", v, n)
+		}
+	}
+	if n := s.Count(func(v interface{}) bool { return v.(int) > 2 }); n != 3 {
+		t.Errorf("expected 3 elements greater than 2, got %d", n)
+	}
+}
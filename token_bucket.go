@@ -0,0 +1,81 @@
+package gods
+
+import (
+	"sync"
+	"time"
+)
+
+// TokenBucket is a rate limiter safe for concurrent use. It holds up to
+// capacity tokens, refilled continuously at a fixed rate, and each allowed
+// event consumes tokens. It allows bursts of up to capacity events, and
+// refillPerSec events per second on average.
+type TokenBucket struct {
+	mu           sync.Mutex
+	clock        Clock
+	capacity     float64
+	refillPerSec float64
+	tokens       float64
+	last         time.Time // when tokens was last refilled
+}
+
+// NewTokenBucket creates a full TokenBucket using the SystemClock. Panics
+// if capacity is not positive or refillPerSec is negative.
+func NewTokenBucket(capacity int, refillPerSec float64) *TokenBucket {
+	return NewTokenBucketWithClock(capacity, refillPerSec, SystemClock)
+}
+
+// NewTokenBucketWithClock creates a full TokenBucket using clock to refill
+// tokens. Panics if capacity is not positive or refillPerSec is negative.
+func NewTokenBucketWithClock(capacity int, refillPerSec float64, clock Clock) *TokenBucket {
+	if capacity <= 0 {
+		panic("gods: TokenBucket capacity must be positive")
+	}
+	if refillPerSec < 0 {
+		panic("gods: TokenBucket refill rate must not be negative")
+	}
+	return &TokenBucket{
+		clock:        clock,
+		capacity:     float64(capacity),
+		refillPerSec: refillPerSec,
+		tokens:       float64(capacity),
+		last:         clock.Now(),
+	}
+}
+
+// Tokens returns the number of tokens currently available, which may be
+// fractional.
+func (b *TokenBucket) Tokens() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	return b.tokens
+}
+
+// Allow reports whether an event may happen now, consuming a token if so.
+func (b *TokenBucket) Allow() bool {
+	return b.AllowN(1)
+}
+
+// AllowN reports whether n events may happen now, consuming n tokens if so.
+// No token is consumed if fewer than n are available.
+func (b *TokenBucket) AllowN(n int) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	if float64(n) > b.tokens {
+		return false
+	}
+	b.tokens -= float64(n)
+	return true
+}
+
+func (b *TokenBucket) refill() {
+	now := b.clock.Now()
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.refillPerSec
+		if b.tokens > b.capacity {
+			b.tokens = b.capacity
+		}
+	}
+	b.last = now
+}
@@ -0,0 +1,55 @@
+package gods
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	clock := newFakeClock()
+	b := NewTokenBucketWithClock(5, 2, clock)
+
+	// A full bucket allows a burst up to its capacity.
+	for i := 0; i < 5; i++ {
+		if !b.Allow() {
+			t.Fatalf("expected event %d of the burst to be allowed", i)
+		}
+	}
+	if b.Allow() {
+		t.Fatal("expected an empty bucket to deny")
+	}
+
+	clock.Advance(250 * time.Millisecond)
+	if b.Allow() {
+		t.Error("expected half a token not to be enough")
+	}
+	clock.Advance(250 * time.Millisecond)
+	if !b.Allow() || b.Allow() {
+		t.Error("expected exactly one token after half a second")
+	}
+
+	clock.Advance(time.Second)
+	if b.AllowN(3) {
+		t.Error("expected 2 tokens not to allow 3 events")
+	}
+	if !b.AllowN(2) {
+		t.Error("expected 2 tokens to allow 2 events")
+	}
+
+	clock.Advance(time.Hour)
+	if tokens := b.Tokens(); tokens != 5 {
+		t.Errorf("expected refill to stop at capacity, got %v tokens", tokens)
+	}
+	if b.AllowN(6) || !b.AllowN(5) {
+		t.Error("expected bursts to be bounded by capacity")
+	}
+}
+
+func TestTokenBucketInvalid(t *testing.T) {
+	if !panics(func() { NewTokenBucket(0, 1) }) {
+		t.Error("expected zero capacity to panic")
+	}
+	if !panics(func() { NewTokenBucket(1, -1) }) {
+		t.Error("expected a negative refill rate to panic")
+	}
+}
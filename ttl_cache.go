@@ -0,0 +1,156 @@
+package gods
+
+import (
+	"sync"
+	"time"
+)
+
+// TTLCache is a Container mapping keys to values which expire after a per
+// entry time-to-live. Expired entries are treated as absent and lazily
+// evicted on access, or proactively by a background sweeper started with
+// StartSweeper. A TTLCache is safe for concurrent use.
+type TTLCache struct {
+	mu      sync.Mutex
+	clock   Clock
+	entries map[interface{}]ttlEntry
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+type ttlEntry struct {
+	value    interface{}
+	deadline time.Time // zero if the entry never expires
+}
+
+func (e ttlEntry) expired(now time.Time) bool {
+	return !e.deadline.IsZero() && !now.Before(e.deadline)
+}
+
+// NewTTLCache creates an empty TTLCache using the SystemClock.
+func NewTTLCache() *TTLCache {
+	return NewTTLCacheWithClock(SystemClock)
+}
+
+// NewTTLCacheWithClock creates an empty TTLCache using clock to tell
+// whether entries are expired.
+func NewTTLCacheWithClock(clock Clock) *TTLCache {
+	return &TTLCache{clock: clock, entries: make(map[interface{}]ttlEntry)}
+}
+
+// Empty indicates if the TTLCache has no unexpired entry.
+func (c *TTLCache) Empty() bool {
+	return c.Size() == 0
+}
+
+// Size retrieves the number of unexpired entries, evicting the expired ones.
+func (c *TTLCache) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.evictExpired()
+	return len(c.entries)
+}
+
+// Clear resets TTLCache, it will be empty with size 0.
+func (c *TTLCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[interface{}]ttlEntry)
+}
+
+// Put maps key to value for the duration of ttl, replacing any previous
+// entry of key. The entry never expires if ttl is not positive.
+func (c *TTLCache) Put(key, value interface{}, ttl time.Duration) {
+	e := ttlEntry{value: value}
+	if ttl > 0 {
+		e.deadline = c.clock.Now().Add(ttl)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = e
+}
+
+// Get finds the value (if any) that is bound to key and not yet expired.
+func (c *TTLCache) Get(key interface{}) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if e.expired(c.clock.Now()) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return e.value, true
+}
+
+// Has checks whether key is bound to an unexpired value.
+func (c *TTLCache) Has(key interface{}) bool {
+	_, ok := c.Get(key)
+	return ok
+}
+
+// Delete removes the entry of key, if present.
+func (c *TTLCache) Delete(key interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// StartSweeper starts a goroutine evicting expired entries every interval,
+// replacing any running sweeper. Call Stop to release it. Panics if
+// interval is not positive, leaving any running sweeper in place.
+func (c *TTLCache) StartSweeper(interval time.Duration) {
+	if interval <= 0 {
+		panic("gods: sweeper interval must be positive")
+	}
+	stop, done := make(chan struct{}), make(chan struct{})
+	c.mu.Lock()
+	oldStop, oldDone := c.stop, c.done
+	c.stop, c.done = stop, done
+	c.mu.Unlock()
+	stopSweeper(oldStop, oldDone)
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.mu.Lock()
+				c.evictExpired()
+				c.mu.Unlock()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the sweeper started by StartSweeper and waits for it to exit.
+// It does nothing if no sweeper is running.
+func (c *TTLCache) Stop() {
+	c.mu.Lock()
+	stop, done := c.stop, c.done
+	c.stop, c.done = nil, nil
+	c.mu.Unlock()
+	stopSweeper(stop, done)
+}
+
+// stopSweeper closes stop and waits for done, unless stop is nil.
+func stopSweeper(stop, done chan struct{}) {
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+func (c *TTLCache) evictExpired() {
+	now := c.clock.Now()
+	for key, e := range c.entries {
+		if e.expired(now) {
+			delete(c.entries, key)
+		}
+	}
+}
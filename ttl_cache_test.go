@@ -0,0 +1,146 @@
+package gods
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Unix(0, 0)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestTTLCache(t *testing.T) {
+	clock := newFakeClock()
+	c := NewTTLCacheWithClock(clock)
+	c.Put("short", 1, time.Second)
+	c.Put("long", 2, time.Minute)
+	c.Put("forever", 3, 0)
+	if c.Size() != 3 {
+		t.Fatalf("expected size 3, got %d", c.Size())
+	}
+
+	clock.Advance(time.Second - 1)
+	if v, ok := c.Get("short"); !ok || v != 1 {
+		t.Errorf("expected short to be alive, got %v, %v", v, ok)
+	}
+
+	clock.Advance(1)
+	if c.Has("short") {
+		t.Error("expected short to expire after its ttl")
+	}
+	if _, ok := c.entries["short"]; ok {
+		t.Error("expected expired entry to be evicted on access")
+	}
+	if !c.Has("long") || c.Size() != 2 {
+		t.Error("expected long to be alive")
+	}
+
+	clock.Advance(time.Hour)
+	if c.Has("long") || !c.Has("forever") || c.Size() != 1 {
+		t.Error("expected only forever to be alive")
+	}
+
+	c.Put("forever", 4, time.Second)
+	if v, _ := c.Get("forever"); v != 4 {
+		t.Errorf("expected Put to replace the entry, got %v", v)
+	}
+	c.Delete("forever")
+	if !c.Empty() {
+		t.Error("expected cache to be empty after Delete")
+	}
+	c.Put("a", 1, 0)
+	c.Clear()
+	if !c.Empty() {
+		t.Error("expected cache to be empty after Clear")
+	}
+}
+
+func TestTTLCacheSweeper(t *testing.T) {
+	clock := newFakeClock()
+	c := NewTTLCacheWithClock(clock)
+	c.Put("a", 1, time.Second)
+	c.Put("b", 2, time.Hour)
+
+	c.StartSweeper(time.Millisecond)
+	defer c.Stop()
+	clock.Advance(time.Minute)
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		c.mu.Lock()
+		n := len(c.entries)
+		c.mu.Unlock()
+		if n == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected sweeper to evict the expired entry")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if !c.Has("b") {
+		t.Error("expected unexpired entry to survive the sweeper")
+	}
+
+	c.Stop()
+	c.Stop()
+}
+
+func TestTTLCacheConcurrentStartSweeper(t *testing.T) {
+	before := runtime.NumGoroutine()
+	c := NewTTLCache()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.StartSweeper(time.Hour)
+		}()
+	}
+	wg.Wait()
+	c.Stop()
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected Stop to leave no sweeper running, %d goroutines left over", runtime.NumGoroutine()-before)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestTTLCacheSweeperInterval(t *testing.T) {
+	c := NewTTLCache()
+	c.StartSweeper(time.Hour)
+	defer c.Stop()
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if !panics(func() { c.StartSweeper(interval) }) {
+			t.Errorf("expected an interval of %v to panic", interval)
+		}
+	}
+	c.mu.Lock()
+	running := c.stop != nil
+	c.mu.Unlock()
+	if !running {
+		t.Error("expected the running sweeper to be kept")
+	}
+}
@@ -0,0 +1,157 @@
+package gods
+
+// UndoableSlice wraps a Slice, recording its mutations so that they can be
+// reverted with Undo and reapplied with Redo. Recording a new mutation
+// discards the mutations which could be redone. The wrapped Slice must only
+// be mutated through the UndoableSlice, or the history no longer applies.
+type UndoableSlice struct {
+	slice Slice
+	// done and undone are stacks of *sliceEdit, kept in LinkedLists used
+	// with AddFirst and RemoveFirst.
+	done, undone *LinkedList
+}
+
+// sliceEdit holds how to revert and reapply a mutation.
+type sliceEdit struct {
+	undo, redo func()
+}
+
+var _ Container = (*UndoableSlice)(nil)
+
+// NewUndoableSlice wraps s into an UndoableSlice with an empty history.
+func NewUndoableSlice(s Slice) *UndoableSlice {
+	return &UndoableSlice{slice: s, done: NewLinkedList(), undone: NewLinkedList()}
+}
+
+// Unwrap returns the wrapped Slice, to be used read-only.
+func (u *UndoableSlice) Unwrap() Slice {
+	return u.slice
+}
+
+// Raw returns the underlying []interface{} of the wrapped Slice.
+func (u *UndoableSlice) Raw() []interface{} {
+	return u.slice.Raw()
+}
+
+// Empty indicates if the UndoableSlice is empty.
+func (u *UndoableSlice) Empty() bool {
+	return u.slice.Empty()
+}
+
+// Size retrieves UndoableSlice size.
+func (u *UndoableSlice) Size() int {
+	return u.slice.Size()
+}
+
+func (u *UndoableSlice) record(undo, redo func()) {
+	u.done.AddFirst(&sliceEdit{undo: undo, redo: redo})
+	u.undone.Clear()
+}
+
+// Clear removes all the elements of UndoableSlice, as an undoable mutation.
+func (u *UndoableSlice) Clear() {
+	old := append([]interface{}(nil), u.slice.Raw()...)
+	u.slice.Clear()
+	u.record(func() { u.slice.Append(old...) }, u.slice.Clear)
+}
+
+// Append adds elements to the end of UndoableSlice.
+func (u *UndoableSlice) Append(values ...interface{}) *UndoableSlice {
+	// Copy values, which the caller may reuse, to redo the mutation later.
+	values = append([]interface{}(nil), values...)
+	u.slice.Append(values...)
+	u.record(func() {
+		for range values {
+			u.slice.Pop()
+		}
+	}, func() { u.slice.Append(values...) })
+	return u
+}
+
+// Prepend adds elements to the start of UndoableSlice.
+func (u *UndoableSlice) Prepend(values ...interface{}) *UndoableSlice {
+	values = append([]interface{}(nil), values...)
+	u.slice.Prepend(values...)
+	u.record(func() {
+		for range values {
+			u.slice.PopFront()
+		}
+	}, func() { u.slice.Prepend(values...) })
+	return u
+}
+
+// Pop removes the last element of UndoableSlice and returns it.
+// Returns (nil, false) and records nothing if the UndoableSlice is empty.
+func (u *UndoableSlice) Pop() (interface{}, bool) {
+	v, ok := u.slice.Pop()
+	if ok {
+		u.record(func() { u.slice.Append(v) }, func() { u.slice.Pop() })
+	}
+	return v, ok
+}
+
+// PopFront removes the first element of UndoableSlice and returns it.
+// Returns (nil, false) and records nothing if the UndoableSlice is empty.
+func (u *UndoableSlice) PopFront() (interface{}, bool) {
+	v, ok := u.slice.PopFront()
+	if ok {
+		u.record(func() { u.slice.Prepend(v) }, func() { u.slice.PopFront() })
+	}
+	return v, ok
+}
+
+// Splice removes elements from UndoableSlice and inserts new elements in
+// their place, like Slice.Splice, returning the deleted elements.
+func (u *UndoableSlice) Splice(start, deleteCount int, elements ...interface{}) Slice {
+	// Resolve start like Splice does, so that the inverse applies at the
+	// same position.
+	size := u.slice.Size()
+	if start < 0 {
+		start += size
+		if start < 0 {
+			start = 0
+		}
+	} else if start > size {
+		start = size
+	}
+	elements = append([]interface{}(nil), elements...)
+	deleted := u.slice.Splice(start, deleteCount, elements...)
+	removed := append([]interface{}(nil), deleted.Raw()...)
+	u.record(func() { u.slice.Splice(start, len(elements), removed...) },
+		func() { u.slice.Splice(start, len(removed), elements...) })
+	return deleted
+}
+
+// CanUndo reports whether there is a mutation to Undo.
+func (u *UndoableSlice) CanUndo() bool {
+	return !u.done.Empty()
+}
+
+// CanRedo reports whether there is an undone mutation to Redo.
+func (u *UndoableSlice) CanRedo() bool {
+	return !u.undone.Empty()
+}
+
+// Undo reverts the last mutation not undone yet, and reports whether there
+// was one.
+func (u *UndoableSlice) Undo() bool {
+	e, ok := u.done.RemoveFirst()
+	if !ok {
+		return false
+	}
+	e.(*sliceEdit).undo()
+	u.undone.AddFirst(e)
+	return true
+}
+
+// Redo reapplies the last undone mutation, and reports whether there was
+// one.
+func (u *UndoableSlice) Redo() bool {
+	e, ok := u.undone.RemoveFirst()
+	if !ok {
+		return false
+	}
+	e.(*sliceEdit).redo()
+	u.done.AddFirst(e)
+	return true
+}
@@ -0,0 +1,88 @@
+package gods
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestUndoableSlice(t *testing.T) {
+	u := NewUndoableSlice(NewArraySlice())
+	if u.Undo() || u.Redo() {
+		t.Fatal("expected nothing to undo or redo")
+	}
+
+	var states []string
+	save := func() { states = append(states, fmt.Sprint(u.Raw())) }
+	save()
+	u.Append(1, 2, 3)
+	save()
+	u.Prepend(0)
+	save()
+	u.Pop()
+	save()
+	u.PopFront()
+	save()
+	if deleted := u.Splice(-2, 10, "a", "b", "c"); !equalRaw(deleted.Raw(), []interface{}{1, 2}) {
+		t.Errorf("expected Splice to delete [1 2], got %v", deleted.Raw())
+	}
+	save()
+	u.Splice(1, 1)
+	save()
+	u.Clear()
+	save()
+	if _, ok := u.Pop(); ok || !u.Empty() {
+		t.Error("expected Pop on an empty slice to fail")
+	}
+
+	want := []string{"[]", "[1 2 3]", "[0 1 2 3]", "[0 1 2]", "[1 2]", "[a b c]", "[a c]", "[]"}
+	if fmt.Sprint(states) != fmt.Sprint(want) {
+		t.Fatalf("expected states %v, got %v", want, states)
+	}
+	for i := len(states) - 2; i >= 0; i-- {
+		if !u.Undo() {
+			t.Fatalf("expected undo back to %s", states[i])
+		}
+		if got := fmt.Sprint(u.Raw()); got != states[i] {
+			t.Fatalf("expected undo back to %s, got %s", states[i], got)
+		}
+	}
+	if u.CanUndo() || u.Undo() {
+		t.Fatal("expected the history to be exhausted")
+	}
+	for i := 1; i < len(states); i++ {
+		if !u.Redo() {
+			t.Fatalf("expected redo to %s", states[i])
+		}
+		if got := fmt.Sprint(u.Raw()); got != states[i] {
+			t.Fatalf("expected redo to %s, got %s", states[i], got)
+		}
+	}
+	if u.CanRedo() {
+		t.Fatal("expected nothing left to redo")
+	}
+
+	u.Undo()
+	u.Undo()
+	u.Append("d")
+	if u.CanRedo() || fmt.Sprint(u.Raw()) != "[a b c d]" {
+		t.Errorf("expected a new mutation to discard redo, got %v", u.Raw())
+	}
+}
+
+func TestUndoableSliceCopiesValues(t *testing.T) {
+	u := NewUndoableSlice(NewArraySlice())
+	buf := []interface{}{1, 2}
+	u.Append(buf...)
+	u.Prepend(buf...)
+	u.Splice(2, 0, buf...)
+	buf[0], buf[1] = "x", "y"
+	u.Undo()
+	u.Undo()
+	u.Undo()
+	u.Redo()
+	u.Redo()
+	u.Redo()
+	if got := fmt.Sprint(u.Raw()); got != "[1 2 1 2 1 2]" {
+		t.Errorf("expected redo to reuse the values passed, got %s", got)
+	}
+}
@@ -0,0 +1,398 @@
+package gods
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// VersionedMap is a Map whose states can be saved as versions with
+// Snapshot, and read back later with AtVersion. It is backed by a
+// persistent hash array mapped trie: updates copy only the path from the
+// root to the changed entry and share everything else, so a Snapshot is
+// O(1) and an update is O(log n) whatever the number of versions. Keys must
+// be comparable, see MustBeComparable.
+type VersionedMap struct {
+	root     *hamtNode
+	size     int
+	versions []hamtVersion
+	readOnly bool
+}
+
+type hamtVersion struct {
+	root *hamtNode
+	size int
+}
+
+// hamtNode is an immutable node of the trie. Bit i of bitmap is set if the
+// node has a slot for the 5 hash bits of value i at its depth, and slots
+// are ordered by i.
+type hamtNode struct {
+	bitmap uint32
+	slots  []hamtSlot
+}
+
+// hamtSlot holds either a child node or a leaf.
+type hamtSlot struct {
+	child *hamtNode
+	leaf  *hamtLeaf
+}
+
+// hamtLeaf holds the pairs of the keys sharing a hash, usually one.
+type hamtLeaf struct {
+	hash  uint64
+	pairs []kvPair
+}
+
+const hamtBits = 5
+
+var (
+	_ Map      = (*VersionedMap)(nil)
+	_ KVRanger = (*VersionedMap)(nil)
+)
+
+// NewVersionedMap creates an empty VersionedMap without any version.
+func NewVersionedMap() *VersionedMap {
+	return &VersionedMap{}
+}
+
+// Snapshot saves the current state of VersionedMap and returns its
+// version, to be passed to AtVersion. Versions count from 0.
+func (m *VersionedMap) Snapshot() int {
+	m.mustBeWritable()
+	m.versions = append(m.versions, hamtVersion{m.root, m.size})
+	return len(m.versions) - 1
+}
+
+// Versions retrieves the number of versions saved by Snapshot.
+func (m *VersionedMap) Versions() int {
+	return len(m.versions)
+}
+
+// AtVersion returns a read-only view of VersionedMap as it was when
+// Snapshot returned version v. Mutating the view panics, while Filter and
+// Map methods return new writable VersionedMaps. Panics if v is not a
+// version of VersionedMap.
+func (m *VersionedMap) AtVersion(v int) Map {
+	if v < 0 || v >= len(m.versions) {
+		panic(fmt.Sprintf("gods: VersionedMap has no version %d", v))
+	}
+	return &VersionedMap{root: m.versions[v].root, size: m.versions[v].size, readOnly: true}
+}
+
+func (m *VersionedMap) mustBeWritable() {
+	if m.readOnly {
+		panic("gods: VersionedMap version is read-only")
+	}
+}
+
+// Empty indicates if the VersionedMap is empty.
+func (m *VersionedMap) Empty() bool {
+	return m.size == 0
+}
+
+// Size retrieves the number of (key,value) pairs of VersionedMap.
+func (m *VersionedMap) Size() int {
+	return m.size
+}
+
+// Clear resets VersionedMap, it will be empty with size 0. The saved
+// versions are kept.
+func (m *VersionedMap) Clear() {
+	m.mustBeWritable()
+	m.root, m.size = nil, 0
+}
+
+// Clone returns an independent, writable copy of VersionedMap in O(1), with
+// its saved versions. The trie is persistent, so it is shared rather than
+// copied.
+func (m *VersionedMap) Clone() Map {
+	return &VersionedMap{
+		root:     m.root,
+		size:     m.size,
+		versions: append([]hamtVersion(nil), m.versions...),
+	}
+}
+
+// Add adds a new (key,value) pair to the VersionedMap, mapping the new key
+// to its new value.
+func (m *VersionedMap) Add(key, value interface{}) Map {
+	m.mustBeWritable()
+	var added bool
+	m.root, added = m.root.assoc(0, hashKey(key), key, value)
+	if added {
+		m.size++
+	}
+	return m
+}
+
+// Get finds the value (if any) that is bound to a given key.
+func (m *VersionedMap) Get(key interface{}) (interface{}, bool) {
+	hash := hashKey(key)
+	for n, shift := m.root, uint(0); n != nil; shift += hamtBits {
+		bit := hamtBit(hash, shift)
+		if n.bitmap&bit == 0 {
+			return nil, false
+		}
+		slot := n.slots[n.index(bit)]
+		if slot.leaf != nil {
+			if slot.leaf.hash == hash {
+				for _, p := range slot.leaf.pairs {
+					if p.key == key {
+						return p.value, true
+					}
+				}
+			}
+			return nil, false
+		}
+		n = slot.child
+	}
+	return nil, false
+}
+
+// Has checks whether the key is in the VersionedMap.
+func (m *VersionedMap) Has(key interface{}) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Delete removes a (key,value) pair from the VersionedMap, unmapping a
+// given key from its value.
+func (m *VersionedMap) Delete(key interface{}) {
+	m.mustBeWritable()
+	var removed bool
+	m.root, removed = m.root.dissoc(0, hashKey(key), key)
+	if removed {
+		m.size--
+	}
+}
+
+// DeleteAll removes the (key,value) pairs of all the keys from the
+// VersionedMap, and returns the number of keys which were present.
+func (m *VersionedMap) DeleteAll(keys ...interface{}) int {
+	before := m.size
+	for _, key := range keys {
+		m.Delete(key)
+	}
+	return before - m.size
+}
+
+// ComputeIfAbsent returns the value bound to key. If key is not in the
+// VersionedMap, supplier is called once and its result is bound to key and
+// returned.
+func (m *VersionedMap) ComputeIfAbsent(key interface{}, supplier func() interface{}) interface{} {
+	m.mustBeWritable()
+	if v, ok := m.Get(key); ok {
+		return v
+	}
+	v := supplier()
+	m.Add(key, v)
+	return v
+}
+
+// ComputeIfPresent rebinds key to the result of remap called with its
+// current value. It does nothing if key is not in the VersionedMap.
+func (m *VersionedMap) ComputeIfPresent(key interface{}, remap func(old interface{}) interface{}) {
+	m.mustBeWritable()
+	if v, ok := m.Get(key); ok {
+		m.Add(key, remap(v))
+	}
+}
+
+// RangeKV iterates over VersionedMap (key,value) pairs in no particular
+// order. Stop iterating if the KVRangerFunc returns false.
+func (m *VersionedMap) RangeKV(fn KVRangerFunc) {
+	m.root.rangeKV(fn)
+}
+
+// Invert returns a new VersionedMap mapping each value to its key. If
+// several keys share the same value, the last one visited wins. Panics if a
+// value is not comparable, see MustBeComparable.
+func (m *VersionedMap) Invert() Map {
+	return m.MapEntries(func(key, value interface{}) (interface{}, interface{}) {
+		MustBeComparable(value)
+		return value, key
+	})
+}
+
+// FilterKeys returns a new VersionedMap with the (key,value) pairs whose
+// key satisfies predicate. The VersionedMap is not modified.
+func (m *VersionedMap) FilterKeys(predicate func(key interface{}) bool) Map {
+	return m.FilterEntries(func(key, _ interface{}) bool { return predicate(key) })
+}
+
+// FilterValues returns a new VersionedMap with the (key,value) pairs whose
+// value satisfies predicate. The VersionedMap is not modified.
+func (m *VersionedMap) FilterValues(predicate func(value interface{}) bool) Map {
+	return m.FilterEntries(func(_, value interface{}) bool { return predicate(value) })
+}
+
+// FilterEntries returns a new VersionedMap with the (key,value) pairs
+// satisfying predicate. The VersionedMap is not modified.
+func (m *VersionedMap) FilterEntries(predicate func(key, value interface{}) bool) Map {
+	filtered := NewVersionedMap()
+	m.RangeKV(func(key, value interface{}) bool {
+		if predicate(key, value) {
+			filtered.Add(key, value)
+		}
+		return true
+	})
+	return filtered
+}
+
+// MapValues returns a new VersionedMap binding each key to its value
+// projected by transform. The VersionedMap is not modified.
+func (m *VersionedMap) MapValues(transform func(value interface{}) interface{}) Map {
+	return m.MapEntries(func(key, value interface{}) (interface{}, interface{}) {
+		return key, transform(value)
+	})
+}
+
+// MapEntries returns a new VersionedMap with each (key,value) pair
+// projected by transform. If several pairs are projected to the same key,
+// the last one visited wins. The VersionedMap is not modified.
+func (m *VersionedMap) MapEntries(transform func(key, value interface{}) (interface{}, interface{})) Map {
+	mapped := NewVersionedMap()
+	m.RangeKV(func(key, value interface{}) bool {
+		mapped.Add(transform(key, value))
+		return true
+	})
+	return mapped
+}
+
+// hamtBit returns the bitmap bit of the 5 bits of hash at shift.
+func hamtBit(hash uint64, shift uint) uint32 {
+	return 1 << ((hash >> shift) & (1<<hamtBits - 1))
+}
+
+// index returns the position of the slot of bit among the slots of n.
+func (n *hamtNode) index(bit uint32) int {
+	return bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+// assoc returns a copy of the subtree n at depth shift with key bound to
+// value, and whether key was added rather than rebound.
+func (n *hamtNode) assoc(shift uint, hash uint64, key, value interface{}) (*hamtNode, bool) {
+	bit := hamtBit(hash, shift)
+	if n == nil {
+		return &hamtNode{bitmap: bit, slots: []hamtSlot{{leaf: &hamtLeaf{hash, []kvPair{{key, value}}}}}}, true
+	}
+	i := n.index(bit)
+	if n.bitmap&bit == 0 {
+		slots := make([]hamtSlot, len(n.slots)+1)
+		copy(slots, n.slots[:i])
+		slots[i] = hamtSlot{leaf: &hamtLeaf{hash, []kvPair{{key, value}}}}
+		copy(slots[i+1:], n.slots[i:])
+		return &hamtNode{bitmap: n.bitmap | bit, slots: slots}, true
+	}
+
+	var slot hamtSlot
+	added := true
+	switch leaf := n.slots[i].leaf; {
+	case leaf == nil:
+		slot.child, added = n.slots[i].child.assoc(shift+hamtBits, hash, key, value)
+	case leaf.hash == hash:
+		slot.leaf, added = leaf.assoc(key, value)
+	default:
+		// Push the leaf one level down, next to the new pair.
+		child := &hamtNode{bitmap: hamtBit(leaf.hash, shift+hamtBits), slots: []hamtSlot{{leaf: leaf}}}
+		slot.child, _ = child.assoc(shift+hamtBits, hash, key, value)
+	}
+	return n.withSlot(i, slot), added
+}
+
+// dissoc returns a copy of the subtree n at depth shift without key, or
+// nil if it would be empty, and whether key was removed.
+func (n *hamtNode) dissoc(shift uint, hash uint64, key interface{}) (*hamtNode, bool) {
+	if n == nil {
+		return nil, false
+	}
+	bit := hamtBit(hash, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	i := n.index(bit)
+	var slot hamtSlot
+	removed := false
+	if leaf := n.slots[i].leaf; leaf != nil {
+		if leaf.hash != hash {
+			return n, false
+		}
+		slot.leaf, removed = leaf.dissoc(key)
+		if slot.leaf == nil && removed {
+			return n.withoutSlot(i, bit), true
+		}
+	} else {
+		slot.child, removed = n.slots[i].child.dissoc(shift+hamtBits, hash, key)
+		if slot.child == nil {
+			return n.withoutSlot(i, bit), true
+		}
+	}
+	if !removed {
+		return n, false
+	}
+	return n.withSlot(i, slot), true
+}
+
+func (n *hamtNode) withSlot(i int, slot hamtSlot) *hamtNode {
+	slots := append([]hamtSlot(nil), n.slots...)
+	slots[i] = slot
+	return &hamtNode{bitmap: n.bitmap, slots: slots}
+}
+
+func (n *hamtNode) withoutSlot(i int, bit uint32) *hamtNode {
+	if len(n.slots) == 1 {
+		return nil
+	}
+	slots := make([]hamtSlot, 0, len(n.slots)-1)
+	slots = append(append(slots, n.slots[:i]...), n.slots[i+1:]...)
+	return &hamtNode{bitmap: n.bitmap &^ bit, slots: slots}
+}
+
+func (n *hamtNode) rangeKV(fn KVRangerFunc) bool {
+	if n == nil {
+		return true
+	}
+	for _, slot := range n.slots {
+		if slot.leaf == nil {
+			if !slot.child.rangeKV(fn) {
+				return false
+			}
+			continue
+		}
+		for _, p := range slot.leaf.pairs {
+			if !fn(p.key, p.value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// assoc returns a copy of leaf with key bound to value, and whether key was
+// added rather than rebound.
+func (leaf *hamtLeaf) assoc(key, value interface{}) (*hamtLeaf, bool) {
+	pairs := append([]kvPair(nil), leaf.pairs...)
+	for i := range pairs {
+		if pairs[i].key == key {
+			pairs[i].value = value
+			return &hamtLeaf{leaf.hash, pairs}, false
+		}
+	}
+	return &hamtLeaf{leaf.hash, append(pairs, kvPair{key, value})}, true
+}
+
+// dissoc returns a copy of leaf without key, or nil if it would be empty,
+// and whether key was removed.
+func (leaf *hamtLeaf) dissoc(key interface{}) (*hamtLeaf, bool) {
+	for i, p := range leaf.pairs {
+		if p.key == key {
+			if len(leaf.pairs) == 1 {
+				return nil, true
+			}
+			pairs := make([]kvPair, 0, len(leaf.pairs)-1)
+			pairs = append(append(pairs, leaf.pairs[:i]...), leaf.pairs[i+1:]...)
+			return &hamtLeaf{leaf.hash, pairs}, true
+		}
+	}
+	return leaf, false
+}
@@ -0,0 +1,195 @@
+package gods
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func checkVersionedMap(t *testing.T, m Map, reference map[interface{}]interface{}) {
+	t.Helper()
+	if m.Size() != len(reference) {
+		t.Fatalf("expected size %d, got %d", len(reference), m.Size())
+	}
+	for k, v := range reference {
+		if got, ok := m.Get(k); !ok || got != v {
+			t.Fatalf("expected %v bound to %v, got %v", k, v, got)
+		}
+	}
+	count := 0
+	m.(KVRanger).RangeKV(func(key, value interface{}) bool {
+		if reference[key] != value {
+			t.Fatalf("unexpected pair (%v, %v)", key, value)
+		}
+		count++
+		return true
+	})
+	if count != len(reference) {
+		t.Fatalf("expected %d pairs visited, got %d", len(reference), count)
+	}
+}
+
+func TestVersionedMap(t *testing.T) {
+	m := NewVersionedMap()
+	m.Add("host", "localhost").Add("port", 80)
+	v0 := m.Snapshot()
+	m.Add("port", 8080).Add("debug", true)
+	m.Delete("host")
+	v1 := m.Snapshot()
+	m.Clear()
+
+	checkVersionedMap(t, m.AtVersion(v0), map[interface{}]interface{}{"host": "localhost", "port": 80})
+	checkVersionedMap(t, m.AtVersion(v1), map[interface{}]interface{}{"port": 8080, "debug": true})
+	if !m.Empty() || m.Versions() != 2 {
+		t.Error("expected Clear to keep the versions")
+	}
+
+	view := m.AtVersion(v0)
+	if !panics(func() { view.Add("host", "example.com") }) || !panics(func() { view.Delete("host") }) {
+		t.Error("expected a version to be read-only")
+	}
+	if !panics(func() { m.AtVersion(2) }) {
+		t.Error("expected an unknown version to panic")
+	}
+	derived := view.MapValues(func(v interface{}) interface{} { return v })
+	derived.Add("user", "root")
+	if derived.Size() != 3 || view.Size() != 2 {
+		t.Error("expected derived maps to be writable copies")
+	}
+}
+
+func TestVersionedMapRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	m := NewVersionedMap()
+	reference := make(map[interface{}]interface{})
+	var snapshots []map[interface{}]interface{}
+	for i := 0; i < 5000; i++ {
+		key := r.Intn(1000)
+		if r.Intn(3) == 0 {
+			m.Delete(key)
+			delete(reference, key)
+		} else {
+			m.Add(key, i)
+			reference[key] = i
+		}
+		if i%500 == 0 {
+			m.Snapshot()
+			copied := make(map[interface{}]interface{}, len(reference))
+			for k, v := range reference {
+				copied[k] = v
+			}
+			snapshots = append(snapshots, copied)
+		}
+	}
+	checkVersionedMap(t, m, reference)
+	for v, snapshot := range snapshots {
+		checkVersionedMap(t, m.AtVersion(v), snapshot)
+	}
+}
+
+func TestVersionedMapHashCollision(t *testing.T) {
+	var root *hamtNode
+	root, _ = root.assoc(0, 42, "a", 1)
+	root, _ = root.assoc(0, 42, "b", 2)
+	if root, added := root.assoc(0, 42, "a", 3); added || len(root.slots[0].leaf.pairs) != 2 {
+		t.Fatal("expected a colliding key to be rebound")
+	}
+	m := &VersionedMap{root: root, size: 2}
+	m.Snapshot()
+	root, removed := root.dissoc(0, 42, "a")
+	if !removed || len(root.slots[0].leaf.pairs) != 1 || root.slots[0].leaf.pairs[0].key != "b" {
+		t.Fatal("expected a colliding key to be removed alone")
+	}
+	if root, _ = root.dissoc(0, 42, "b"); root != nil {
+		t.Error("expected an empty trie to be nil")
+	}
+	if m.AtVersion(0).Size() != 2 {
+		t.Error("expected the version to keep both colliding keys")
+	}
+}
+
+func TestVersionedMapCompute(t *testing.T) {
+	m := NewVersionedMap()
+	m.ComputeIfAbsent("n", func() interface{} { return 1 })
+	v := m.Snapshot()
+	m.ComputeIfPresent("n", func(old interface{}) interface{} { return old.(int) + 1 })
+	if got, _ := m.Get("n"); got != 2 {
+		t.Errorf("expected n remapped to 2, got %v", got)
+	}
+	if got, _ := m.AtVersion(v).Get("n"); got != 1 {
+		t.Errorf("expected the version to keep 1, got %v", got)
+	}
+	if inverted := m.Invert(); !inverted.Has(2) {
+		t.Error("expected 2 to be inverted to n")
+	}
+	if m.FilterKeys(func(key interface{}) bool { return key != "n" }).Size() != 0 {
+		t.Error("expected n to be filtered out")
+	}
+}
+
+func TestVersionedMapDeleteAll(t *testing.T) {
+	m := NewVersionedMap()
+	m.Add("a", 1).Add("b", 2).Add("c", 3)
+	v := m.Snapshot()
+	if n := m.DeleteAll("a", "x", "c", "a"); n != 2 {
+		t.Errorf("expected 2 keys removed, got %d", n)
+	}
+	if m.Size() != 1 || m.AtVersion(v).Size() != 3 {
+		t.Error("expected the version to keep the deleted keys")
+	}
+}
+
+func TestVersionedMapClone(t *testing.T) {
+	m := NewVersionedMap()
+	reference := make(map[interface{}]interface{})
+	for i := 0; i < 100; i++ {
+		m.Add(i, i)
+		reference[i] = i
+	}
+	v := m.Snapshot()
+
+	clone := m.Clone().(*VersionedMap)
+	clone.Add(0, "rebound")
+	clone.Delete(1)
+	clone.Snapshot()
+	checkVersionedMap(t, m, reference)
+	if m.Versions() != 1 || clone.Versions() != 2 {
+		t.Errorf("expected versions to be independent, got %d and %d", m.Versions(), clone.Versions())
+	}
+	checkVersionedMap(t, clone.AtVersion(v), reference)
+
+	// Cloning a read-only view gives a writable VersionedMap.
+	view := m.AtVersion(v).Clone()
+	view.Add(100, 100)
+	checkVersionedMap(t, m.AtVersion(v), reference)
+	if view.Size() != 101 {
+		t.Errorf("expected the clone of a view to be writable, got size %d", view.Size())
+	}
+}
+
+func TestVersionedMapSignedZeroKeys(t *testing.T) {
+	type point struct{ x, y float32 }
+	negZero := float32(math.Copysign(0, -1))
+	tests := []struct {
+		name     string
+		pos, neg interface{}
+	}{
+		{"float32", float32(0), negZero},
+		{"struct", point{0, 1}, point{negZero, 1}},
+	}
+	for _, tt := range tests {
+		m := NewVersionedMap()
+		m.Add(tt.pos, 1)
+		if v, ok := m.Get(tt.neg); !ok || v != 1 {
+			t.Errorf("%s: expected -0 to find the 0 key, got (%v, %v)", tt.name, v, ok)
+		}
+		m.Add(tt.neg, 2)
+		if m.Size() != 1 {
+			t.Errorf("%s: expected -0 to rebind the 0 key, got size %d", tt.name, m.Size())
+		}
+		m.Delete(tt.neg)
+		if !m.Empty() {
+			t.Errorf("%s: expected -0 to delete the 0 key", tt.name)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package gods
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+)
+
+// WeightedSampler draws elements at random from a discrete distribution in
+// O(1) per draw, using Walker's alias method built in O(n).
+type WeightedSampler struct {
+	items []interface{}
+	prob  []float64 // probability to keep column i instead of its alias
+	alias []int
+	rand  *rand.Rand
+}
+
+// NewWeightedSampler creates a WeightedSampler drawing the elements of
+// items, each with a probability proportional to its weight, using r as
+// source of randomness. Returns an error if items and weights have
+// different sizes, there are no items, a weight is negative or not a
+// number, or all the weights are zero.
+func NewWeightedSampler(items Slice, weights []float64, r *rand.Rand) (*WeightedSampler, error) {
+	raw := items.Raw()
+	if len(raw) != len(weights) {
+		return nil, errors.New("gods: items and weights must have the same size")
+	}
+	if len(raw) == 0 {
+		return nil, errors.New("gods: no item to sample")
+	}
+	total := 0.0
+	for _, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return nil, errors.New("gods: weights must be finite and not negative")
+		}
+		total += w
+	}
+	if total == 0 {
+		return nil, errors.New("gods: weights must not all be zero")
+	}
+
+	n := len(weights)
+	s := &WeightedSampler{
+		items: append([]interface{}(nil), raw...),
+		prob:  make([]float64, n),
+		alias: make([]int, n),
+		rand:  r,
+	}
+
+	// Vose's algorithm: scale the weights so that they average 1, then pair
+	// each column under 1 with a column over 1 which fills it up.
+	scaled := make([]float64, n)
+	var small, large []int
+	for i, w := range weights {
+		scaled[i] = w * float64(n) / total
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		l, g := small[len(small)-1], large[len(large)-1]
+		small = small[:len(small)-1]
+		s.prob[l], s.alias[l] = scaled[l], g
+		scaled[g] += scaled[l] - 1
+		if scaled[g] < 1 {
+			large = large[:len(large)-1]
+			small = append(small, g)
+		}
+	}
+	// The remaining columns are full, up to rounding errors.
+	for _, i := range append(small, large...) {
+		s.prob[i], s.alias[i] = 1, i
+	}
+	return s, nil
+}
+
+// Sample draws an element.
+func (s *WeightedSampler) Sample() interface{} {
+	i := s.rand.Intn(len(s.items))
+	if s.rand.Float64() < s.prob[i] {
+		return s.items[i]
+	}
+	return s.items[s.alias[i]]
+}
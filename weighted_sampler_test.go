@@ -0,0 +1,58 @@
+package gods
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestWeightedSampler(t *testing.T) {
+	weights := []float64{1, 0, 2, 3, 4, 0.5}
+	s, err := NewWeightedSampler(NewArraySlice("a", "b", "c", "d", "e", "f"), weights, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	const draws = 200000
+	counts := make(map[interface{}]int)
+	for i := 0; i < draws; i++ {
+		counts[s.Sample()]++
+	}
+
+	total := 10.5
+	for i, item := range []string{"a", "b", "c", "d", "e", "f"} {
+		want := weights[i] / total
+		got := float64(counts[item]) / draws
+		if math.Abs(got-want) > 0.01 {
+			t.Errorf("expected %s to be drawn with probability %.3f, got %.3f", item, want, got)
+		}
+	}
+	if counts["b"] != 0 {
+		t.Errorf("expected zero weight item never to be drawn, got %d", counts["b"])
+	}
+
+	single, _ := NewWeightedSampler(NewArraySlice("x"), []float64{0.1}, rand.New(rand.NewSource(1)))
+	if v := single.Sample(); v != "x" {
+		t.Errorf("expected the single item, got %v", v)
+	}
+}
+
+func TestNewWeightedSamplerErrors(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tests := map[string]struct {
+		items   *ArraySlice
+		weights []float64
+	}{
+		"mismatched lengths": {NewArraySlice(1, 2), []float64{1}},
+		"no items":           {NewArraySlice(), nil},
+		"negative weight":    {NewArraySlice(1, 2), []float64{1, -1}},
+		"NaN weight":         {NewArraySlice(1), []float64{math.NaN()}},
+		"infinite weight":    {NewArraySlice(1), []float64{math.Inf(1)}},
+		"zero weights":       {NewArraySlice(1, 2), []float64{0, 0}},
+	}
+	for name, tt := range tests {
+		if _, err := NewWeightedSampler(tt.items, tt.weights, r); err == nil {
+			t.Errorf("expected error for %s", name)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package gods
+
+// WindowExtrema slides a window of size k over values and returns a Slice
+// with the extremum of each window, the element e for which cmp(e, other)
+// is not negative for every other element of the window. Pass a comparison
+// function for the natural order to get the window maxima, and a reversed
+// one to get the window minima. Ties are resolved in favor of the latest
+// element.
+//
+// The result is computed in O(n) with a monotone queue of window indexes.
+// Returns an empty Slice if k is larger than the size of values, and panics
+// if k is not positive.
+func WindowExtrema(values Slice, k int, cmp func(a, b interface{}) int) Slice {
+	if k <= 0 {
+		panic("gods: window size must be positive")
+	}
+	result := values.Slice(0, 0)
+	raw := values.Raw()
+	if k > len(raw) {
+		return result
+	}
+
+	// monotone holds indexes into raw whose values are monotonically
+	// decreasing according to cmp, the front is the current extremum.
+	monotone := make([]int, 0, k)
+	for i, v := range raw {
+		if len(monotone) > 0 && monotone[0] <= i-k {
+			monotone = monotone[1:]
+		}
+		for len(monotone) > 0 && cmp(raw[monotone[len(monotone)-1]], v) <= 0 {
+			monotone = monotone[:len(monotone)-1]
+		}
+		monotone = append(monotone, i)
+		if i >= k-1 {
+			result.Append(raw[monotone[0]])
+		}
+	}
+	return result
+}
@@ -0,0 +1,53 @@
+package gods
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func bruteWindowExtrema(raw []interface{}, k int, cmp func(a, b interface{}) int) []interface{} {
+	var result []interface{}
+	for i := 0; i+k <= len(raw); i++ {
+		extremum := raw[i]
+		for _, v := range raw[i+1 : i+k] {
+			if cmp(v, extremum) >= 0 {
+				extremum = v
+			}
+		}
+		result = append(result, extremum)
+	}
+	return result
+}
+
+func TestWindowExtrema(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	raw := make([]interface{}, 50)
+	for i := range raw {
+		raw[i] = r.Intn(20)
+	}
+	reversed := func(a, b interface{}) int { return intCompare(b, a) }
+
+	for _, k := range []int{1, 2, 3, 7, len(raw)} {
+		for name, cmp := range map[string]func(a, b interface{}) int{
+			"max": intCompare,
+			"min": reversed,
+		} {
+			got := WindowExtrema(NewArraySlice(raw...), k, cmp).Raw()
+			want := bruteWindowExtrema(raw, k, cmp)
+			if !equalRaw(got, want) {
+				t.Errorf("%s with k=%d: expected %v, got %v", name, k, want, got)
+			}
+		}
+	}
+
+	if got := WindowExtrema(NewArraySlice(1, 2), 3, intCompare); !got.Empty() {
+		t.Errorf("expected empty result for oversized window, got %v", got.Raw())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for non-positive window size")
+		}
+	}()
+	WindowExtrema(NewArraySlice(1), 0, intCompare)
+}